@@ -0,0 +1,127 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// DefaultPath is name of baseline file used when no other path is specified
+const DefaultPath = ".indiff-baseline.json"
+
+// Entry identifies one accepted difference by its kind, language and path to base file
+type Entry struct {
+	Kind indiff.Kind `json:"kind"`
+	Lang string      `json:"lang"`
+	// Path to base file relative to root of the baseline
+	Path string `json:"path"`
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%s: %s: %s", e.Lang, e.Kind, e.Path)
+}
+
+// Baseline holds snapshot of known differences which should not be reported again
+type Baseline struct {
+	root    string
+	entries map[Entry]bool
+}
+
+// file is serialized form of Baseline
+type file struct {
+	Entries []Entry `json:"entries"`
+}
+
+// New creates baseline from given diffs. Paths are stored relative to given root.
+func New(root string, diffs indiff.Diffs) *Baseline {
+	b := &Baseline{root: root, entries: map[Entry]bool{}}
+	for _, d := range diffs {
+		b.entries[b.entryOf(d)] = true
+	}
+	return b
+}
+
+// Read loads baseline from file on given path. Paths in baseline are resolved against given root.
+func Read(path string, root string) (*Baseline, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read baseline file: %s", path)
+	}
+	var f file
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, errors.Wrapf(err, "Invalid baseline file: %s", path)
+	}
+	b := &Baseline{root: root, entries: map[Entry]bool{}}
+	for _, e := range f.Entries {
+		b.entries[e] = true
+	}
+	return b, nil
+}
+
+// Write stores baseline to file on given path
+func (b *Baseline) Write(path string) error {
+	content, err := json.MarshalIndent(&file{Entries: b.Entries()}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Unable to serialize baseline")
+	}
+	content = append(content, '\n')
+	if err := ioutil.WriteFile(path, content, os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write baseline file: %s", path)
+	}
+	return nil
+}
+
+// Entries returns all entries sorted by path, language and kind
+func (b *Baseline) Entries() []Entry {
+	entries := make([]Entry, 0, len(b.entries))
+	for e := range b.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Lang != b.Lang {
+			return a.Lang < b.Lang
+		}
+		return a.Kind < b.Kind
+	})
+	return entries
+}
+
+// Filter splits given diffs to the new ones, which are not part of baseline, and returns them.
+// It returns also baseline entries which are not found in diffs anymore and can be removed from baseline.
+func (b *Baseline) Filter(diffs indiff.Diffs) (fresh indiff.Diffs, removable []Entry) {
+	found := map[Entry]bool{}
+	fresh = indiff.Diffs{}
+	for _, d := range diffs {
+		e := b.entryOf(d)
+		found[e] = true
+		if !b.entries[e] {
+			fresh = append(fresh, d)
+		}
+	}
+	removable = []Entry{}
+	for _, e := range b.Entries() {
+		if !found[e] {
+			removable = append(removable, e)
+		}
+	}
+	return fresh, removable
+}
+
+// entryOf creates baseline entry for given diff
+func (b *Baseline) entryOf(d indiff.Diff) Entry {
+	path := d.Base().Path
+	if rel, err := filepath.Rel(b.root, path); err == nil {
+		path = rel
+	}
+	return Entry{Kind: d.Kind(), Lang: d.Lang(), Path: filepath.ToSlash(path)}
+}
@@ -0,0 +1,38 @@
+package baseline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/unravela/indiff"
+)
+
+func TestFilter(t *testing.T) {
+
+	// Given baseline with one missing and one modified file
+	baseline := New("/doc", indiff.Diffs{
+		indiff.NewMissing(indiff.NewFile("/doc/en/first.md", "en"), "de"),
+		indiff.NewModifiedBase(indiff.NewFile("/doc/en/second.md", "en").Modified(nil), indiff.NewFile("/doc/de/second.md", "de")),
+	})
+
+	// Given current diffs where modified file was fixed and new missing file appeared
+	missing := indiff.NewMissing(indiff.NewFile("/doc/en/third.md", "en"), "de")
+	diffs := indiff.Diffs{
+		indiff.NewMissing(indiff.NewFile("/doc/en/first.md", "en"), "de"),
+		missing,
+	}
+
+	// When diffs are filtered
+	fresh, removable := baseline.Filter(diffs)
+
+	// Then only new missing file should be reported
+	if !reflect.DeepEqual(fresh, indiff.Diffs{missing}) {
+		t.Errorf("Unexpected new differences. Should be `%s` but was `%s`", indiff.Diffs{missing}, fresh)
+	}
+
+	// Then fixed modified file should be removable
+	expected := []Entry{{Kind: indiff.KindModifiedBase, Lang: "de", Path: "en/second.md"}}
+	if !reflect.DeepEqual(removable, expected) {
+		t.Errorf("Unexpected removable entries. Should be `%s` but was `%s`", expected, removable)
+	}
+}
@@ -1,5 +1,11 @@
 package indiff
 
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
 // Bundle holds files in base language and corresponsing translation files in different languages
 type Bundle struct {
 	baselang        string
@@ -7,28 +13,94 @@ type Bundle struct {
 	filesByBasepath map[string]map[string]*File
 }
 
-// NewBundle creates bundle with for specified baselang and files collection
+// KeyFunc returns language independent keys of given file.
+// Files in different languages are translations of each other when they have common key.
+type KeyFunc func(f *File) []string
+
+// KeyPlaceholder replaces language code in keys. It's control character which is not expected in paths,
+// zero character can't be used as it terminates glob patterns which match keys.
+const KeyPlaceholder = "\x01"
+
+// LangKeys creates key for each occurrence of language code in given path by replacing it with KeyPlaceholder.
+// Only keys accepted by given match function are returned, e.g. keys matching pattern of paths.
+func LangKeys(path string, lang string, match func(key string) bool) []string {
+	keys := []string{}
+	if lang == "" {
+		return keys
+	}
+	for i := strings.Index(path, lang); i >= 0; {
+		if key := path[:i] + KeyPlaceholder + path[i+len(lang):]; match(key) {
+			keys = append(keys, key)
+		}
+		next := strings.Index(path[i+1:], lang)
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return keys
+}
+
+// PathKeys is default KeyFunc used when pattern of paths is not known. It creates keys by LangKeys
+// only for language code which is whole part of path delimited by separators or dots (e.g. `de/first.md`
+// or `first.de.md`), so it ignores directories like `de-team`. Unlike File.IsEqualInOtherLang,
+// it pairs also files in languages with codes of different length.
+func PathKeys(f *File) []string {
+	return LangKeys(f.Path, f.Lang, isDelimited)
+}
+
+// isDelimited checks that placeholder in given key is delimited by path separators, dots or ends of key
+func isDelimited(key string) bool {
+	i := strings.Index(key, KeyPlaceholder)
+	end := i + len(KeyPlaceholder)
+	return (i == 0 || isDelimiter(key[i-1])) && (end == len(key) || isDelimiter(key[end]))
+}
+
+// isDelimiter checks if given character delimits parts of path
+func isDelimiter(c byte) bool {
+	return c == '/' || c == '.' || c == filepath.Separator
+}
+
+// NewBundle creates bundle with for specified baselang and files collection.
+// Files are paired by keys created by PathKeys.
 func NewBundle(baselang string, files Files) *Bundle {
+	return NewBundleWithKeys(baselang, files, PathKeys)
+}
+
+// NewBundleWithKeys creates bundle with for specified baselang and files collection.
+// Files are paired by keys created by given function, first file with common key is used in each language.
+func NewBundleWithKeys(baselang string, files Files, keysOf KeyFunc) *Bundle {
 	filesByLang := map[string]Files{}
 	for _, f := range files {
 		filesByLang[f.Lang] = append(filesByLang[f.Lang], f)
 	}
 
+	// index translations by key and language
+	filesByKey := map[string]map[string]*File{}
+	for lang, files := range filesByLang {
+		// skip base lang to not check "against self"
+		if lang == baselang {
+			continue
+		}
+		for _, f := range files {
+			for _, key := range keysOf(f) {
+				if filesByKey[key] == nil {
+					filesByKey[key] = map[string]*File{}
+				}
+				if _, ok := filesByKey[key][lang]; !ok {
+					filesByKey[key][lang] = f
+				}
+			}
+		}
+	}
+
 	filesByBasePath := make(map[string]map[string]*File, len(filesByLang[baselang]))
 	for _, bf := range filesByLang[baselang] {
-
 		filesByBasePath[bf.Path] = map[string]*File{}
-
-		for lang, files := range filesByLang {
-			// skip base lang to not check "against self"
-			if lang == baselang {
-				continue
-			}
-
-			for _, f := range files {
-				if bf.IsEqualInOtherLang(f) {
-					filesByBasePath[bf.Path][f.Lang] = f
-					break
+		for _, key := range keysOf(bf) {
+			for lang, f := range filesByKey[key] {
+				if _, ok := filesByBasePath[bf.Path][lang]; !ok {
+					filesByBasePath[bf.Path][lang] = f
 				}
 			}
 		}
@@ -46,30 +118,32 @@ func (b *Bundle) BaseLang() string {
 	return b.baselang
 }
 
-// Langs lists all languages in bundle including base language
+// Langs lists all languages in bundle including base language, languages are sorted
 func (b *Bundle) Langs() []string {
-	langs := make([]string, len(b.filesByLang))
+	langs := make([]string, 0, len(b.filesByLang))
 	for l := range b.filesByLang {
 		langs = append(langs, l)
 	}
+	sort.Strings(langs)
 	return langs
 }
 
-// BaseFiles returns all files in base language
+// BaseFiles returns all files in base language sorted by path
 func (b *Bundle) BaseFiles() Files {
 	files := Files{}
-	for p := range b.filesByBasepath {
+	for _, p := range b.BasePaths() {
 		files = append(files, NewFile(p, b.baselang))
 	}
 	return files
 }
 
-// BasePaths returns paths to all files in base language
+// BasePaths returns sorted paths to all files in base language
 func (b *Bundle) BasePaths() []string {
-	paths := []string{}
+	paths := make([]string, 0, len(b.filesByBasepath))
 	for p := range b.filesByBasepath {
 		paths = append(paths, p)
 	}
+	sort.Strings(paths)
 	return paths
 }
 
@@ -83,7 +157,7 @@ func (b *Bundle) FileInLang(basepath string, lang string) *File {
 	return b.filesByBasepath[basepath][lang]
 }
 
-// FilesInOtherLangs returns all files with transaltion of file specified by basepath
+// FilesInOtherLangs returns all files with transaltion of file specified by basepath sorted by language
 func (b *Bundle) FilesInOtherLangs(basepath string) Files {
 	section := b.filesByBasepath[basepath]
 	if section == nil {
@@ -93,5 +167,20 @@ func (b *Bundle) FilesInOtherLangs(basepath string) Files {
 	for _, f := range section {
 		files = append(files, f)
 	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Lang < files[j].Lang })
 	return files
 }
+
+// Coverage returns percentage of files in base language which have translation in specified language
+func (b *Bundle) Coverage(lang string) float64 {
+	if lang == b.baselang || len(b.filesByBasepath) == 0 {
+		return 100
+	}
+	translated := 0
+	for p := range b.filesByBasepath {
+		if b.FileInLang(p, lang) != nil {
+			translated++
+		}
+	}
+	return 100 * float64(translated) / float64(len(b.filesByBasepath))
+}
@@ -0,0 +1,152 @@
+package indiff
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBundleOrder(t *testing.T) {
+
+	// Given bundle with files in random order
+	bundle := NewBundle("en", Files{
+		NewFile("sk/c.md", "sk"),
+		NewFile("en/c.md", "en"),
+		NewFile("de/c.md", "de"),
+		NewFile("en/a.md", "en"),
+		NewFile("en/b.md", "en"),
+	})
+
+	// Then paths, files and languages should be sorted
+	paths := []string{"en/a.md", "en/b.md", "en/c.md"}
+	for i := 0; i < 10; i++ {
+		if !reflect.DeepEqual(bundle.BasePaths(), paths) {
+			t.Errorf("Unexpected base paths. Should be `%v` but was `%v`", paths, bundle.BasePaths())
+		}
+		if f := bundle.BaseFiles(); len(f) != 3 || f[0].Path != "en/a.md" || f[2].Path != "en/c.md" {
+			t.Errorf("Unexpected base files. Should be sorted but was `%v`", f)
+		}
+		if f := bundle.FilesInOtherLangs("en/c.md"); len(f) != 2 || f[0].Lang != "de" || f[1].Lang != "sk" {
+			t.Errorf("Unexpected translations. Should be sorted by language but was `%v`", f)
+		}
+	}
+	langs := []string{"de", "en", "sk"}
+	if !reflect.DeepEqual(bundle.Langs(), langs) {
+		t.Errorf("Unexpected languages. Should be `%v` but was `%v`", langs, bundle.Langs())
+	}
+}
+
+func TestBundlePairing(t *testing.T) {
+
+	// Given files where language code appears multiple times in path and languages have codes of different length
+	bundle := NewBundle("en", Files{
+		NewFile("en/guide.en.md", "en"),
+		NewFile("en/ten.md", "en"),
+		NewFile("de/guide.en.md", "de"),
+		NewFile("pt-BR/ten.md", "pt-BR"),
+		NewFile("de/other.md", "de"),
+	})
+
+	// Then files should be paired by language code on any position
+	if f := bundle.FileInLang("en/guide.en.md", "de"); f == nil || f.Path != "de/guide.en.md" {
+		t.Errorf("Unexpected translation. Should be `%s` but was `%v`", "de/guide.en.md", f)
+	}
+	if f := bundle.FileInLang("en/ten.md", "pt-BR"); f == nil || f.Path != "pt-BR/ten.md" {
+		t.Errorf("Unexpected translation. Should be `%s` but was `%v`", "pt-BR/ten.md", f)
+	}
+	if f := bundle.FileInLang("en/ten.md", "de"); f != nil {
+		t.Errorf("Unexpected translation. Should be nil but was `%v`", f)
+	}
+}
+
+func TestPathKeys(t *testing.T) {
+
+	// Given file in directory which name starts with language code
+	f := NewFile("/home/de-team/doc/de/first.de.md", "de")
+
+	// When keys of file are created
+	keys := PathKeys(f)
+
+	// Then only language codes delimited by separators or dots should be replaced
+	expected := []string{"/home/de-team/doc/" + KeyPlaceholder + "/first.de.md", "/home/de-team/doc/de/first." + KeyPlaceholder + ".md"}
+	if !reflect.DeepEqual(expected, keys) {
+		t.Errorf("Unexpected keys. Should be `%q` but was `%q`", expected, keys)
+	}
+}
+
+// syntheticFiles creates files of tree with given count of files in each of given count of languages
+func syntheticFiles(langs int, count int) Files {
+	files := Files{}
+	for l := 0; l < langs; l++ {
+		lang := fmt.Sprintf("l%02d", l)
+		for i := 0; i < count; i++ {
+			files = append(files, NewFile(fmt.Sprintf("/doc/%s/section-%d/page-%d.md", lang, i%50, i), lang))
+		}
+	}
+	return files
+}
+
+// newBundleByComparison pairs files by comparing each base file with each file in other languages
+// as NewBundle did before pairing by keys, it's used as baseline in benchmarks
+func newBundleByComparison(baselang string, files Files) map[string]map[string]*File {
+	filesByLang := map[string]Files{}
+	for _, f := range files {
+		filesByLang[f.Lang] = append(filesByLang[f.Lang], f)
+	}
+	pairs := map[string]map[string]*File{}
+	for _, bf := range filesByLang[baselang] {
+		pairs[bf.Path] = map[string]*File{}
+		for lang, files := range filesByLang {
+			if lang == baselang {
+				continue
+			}
+			for _, f := range files {
+				if bf.IsEqualInOtherLang(f) {
+					pairs[bf.Path][f.Lang] = f
+					break
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+func BenchmarkNewBundle(b *testing.B) {
+	for _, count := range []int{100, 1000, 10000} {
+		files := syntheticFiles(20, count)
+		b.Run(fmt.Sprintf("keys-20x%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewBundle("l00", files)
+			}
+		})
+		if count > 1000 {
+			// quadratic pairing takes minutes for big trees
+			continue
+		}
+		b.Run(fmt.Sprintf("comparison-20x%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newBundleByComparison("l00", files)
+			}
+		})
+	}
+}
+
+func TestSyntheticBundle(t *testing.T) {
+	// Given synthetic tree
+	files := syntheticFiles(3, 100)
+
+	// When bundle is created
+	bundle := NewBundle("l00", files)
+
+	// Then it should pair same files as comparison of each file with each other
+	for basepath, expected := range newBundleByComparison("l00", files) {
+		for lang, f := range expected {
+			if bundle.FileInLang(basepath, lang) != f {
+				t.Errorf("Unexpected translation of %s. Should be `%v` but was `%v`", basepath, f, bundle.FileInLang(basepath, lang))
+			}
+		}
+	}
+	if len(bundle.FilesInOtherLangs("/doc/l00/section-1/page-1.md")) != 2 {
+		t.Errorf("Unexpected translations of synthetic file. Should be 2 but was `%v`", bundle.FilesInOtherLangs("/doc/l00/section-1/page-1.md"))
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/git"
+)
+
+var ackCommand = &cli.Command{
+	Name:      "ack",
+	Usage:     "Acknowledges that translations are up-to-date with current content of base files",
+	ArgsUsage: "languages base-path...",
+	Flags:     pickFlags(diffFlags, "baselang", "glob", "directory", "extensions", "acks"),
+	Action:    ack,
+}
+
+func ack(c *cli.Context) error {
+	langs, baselang, err := parseLangs(c)
+	if err != nil {
+		return err
+	}
+	if c.NArg() < 2 {
+		cli.ShowSubcommandHelp(c)
+		return fmt.Errorf("Missing required argument: base-path")
+	}
+	root, err := filepath.Abs(c.String("directory"))
+	if err != nil {
+		return errors.Wrap(err, "Invalid argument: directory")
+	}
+	pattern, err := filesystem.ParsePattern(c.String("glob"), c.StringSlice("extensions"))
+	if err != nil {
+		return errors.Wrap(err, "Invalid argument: glob")
+	}
+	bundle, err := collectBundle(root, pattern, langs, baselang)
+	if err != nil {
+		return err
+	}
+	basepaths, err := resolveBasePaths(root, bundle, c.Args().Tail())
+	if err != nil {
+		return err
+	}
+
+	path := resolvePath(root, c.String("acks"))
+	acks, err := git.ReadAcks(path, root)
+	if err != nil {
+		return err
+	}
+	for _, basepath := range basepaths {
+		for _, lang := range langs {
+			if lang == baselang {
+				continue
+			}
+			if err := acks.Add(basepath, lang); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "INFO: Translation of %s to %s acknowledged\n", relativeTo(root, basepath), lang)
+		}
+	}
+	return acks.Write(path)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/baseline"
+)
+
+var baselineCommand = &cli.Command{
+	Name:  "baseline",
+	Usage: "Manages baseline file with accepted differences",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "write",
+			Usage:     "Writes all current differences to baseline file",
+			ArgsUsage: "languages",
+			Flags:     diffFlags,
+			Action:    writeBaseline,
+		},
+	},
+}
+
+func writeBaseline(c *cli.Context) error {
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+	path := a.resolve(c.String("baseline"))
+	if err := baseline.New(a.root, a.diffs).Write(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "INFO: %d difference(s) written to baseline: %s\n", len(a.diffs), path)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/handoff"
+)
+
+var exportCommand = &cli.Command{
+	Name:      "export",
+	Usage:     "Exports package with files needed for translation for each language",
+	ArgsUsage: "languages",
+	Flags: concatFlags(diffFlags, []cli.Flag{
+		&cli.StringFlag{
+			Name:    "output",
+			Usage:   "Output directory `PATH` where packages are created",
+			Aliases: []string{"o"},
+			Value:   "indiff-export",
+		},
+		&cli.BoolFlag{
+			Name:  "zip",
+			Usage: "Create zip archive instead of directory for each package",
+			Value: false,
+		},
+	}),
+	Action: export,
+}
+
+func export(c *cli.Context) error {
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+
+	e := &handoff.Export{
+		Root:     a.root,
+		BaseLang: a.baselang,
+		Revision: a.revision,
+		Zip:      c.Bool("zip"),
+	}
+	packages, err := e.Write(c.String("output"), a.diffs)
+	if err != nil {
+		return err
+	}
+	for _, p := range packages {
+		fmt.Fprintf(os.Stderr, "INFO: Package created: %s\n", p)
+	}
+	return nil
+}
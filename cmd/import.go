@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/git"
+	"github.com/unravela/indiff/handoff"
+)
+
+var importCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Imports translated files from package created by export",
+	ArgsUsage: "package",
+	Flags: concatFlags(pickFlags(diffFlags, "glob", "directory", "extensions", "no-git"), []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite translations modified since export",
+			Value: false,
+		},
+	}),
+	Action: importPackage,
+}
+
+func importPackage(c *cli.Context) error {
+	pkg := c.Args().First()
+	if pkg == "" {
+		cli.ShowSubcommandHelp(c)
+		return fmt.Errorf("Missing required argument: package")
+	}
+
+	root, err := filepath.Abs(c.String("directory"))
+	if err != nil {
+		return errors.Wrap(err, "Invalid argument: directory")
+	}
+	pattern, err := filesystem.ParsePattern(c.String("glob"), c.StringSlice("extensions"))
+	if err != nil {
+		return errors.Wrap(err, "Invalid argument: glob")
+	}
+
+	// resolve current revision
+	revision := ""
+	if !c.Bool("no-git") {
+		g, err := git.OpenGit(root, git.Uncommited)
+		if err == git.ErrRepoNotFound {
+			fmt.Fprintf(os.Stderr, "WARN: Git repository was not found. Check your path or use --no-git to hide this warning.\n")
+		} else if err != nil {
+			return errors.Wrap(err, "Error during opening Git repository")
+		} else if revision, err = g.Revision(); err != nil {
+			return err
+		}
+	}
+
+	i := &handoff.Import{
+		Root:     root,
+		Pattern:  pattern,
+		Revision: revision,
+		Force:    c.Bool("force"),
+	}
+	report, err := i.Read(pkg)
+	if err != nil {
+		return err
+	}
+
+	// print report
+	if report.RevisionChanged {
+		fmt.Fprintf(os.Stderr, "WARN: Package was exported from revision %s, current revision is %s\n", report.Manifest.Revision, revision)
+	}
+	for _, p := range report.ChangedBases {
+		fmt.Fprintf(os.Stderr, "WARN: Base file was modified since export: %s\n", p)
+	}
+	for _, p := range report.Untranslated {
+		fmt.Fprintf(os.Stderr, "WARN: Package does not contain translation of: %s\n", p)
+	}
+	for _, p := range report.Imported {
+		fmt.Fprintf(os.Stderr, "INFO: Translation imported: %s\n", p)
+	}
+	for _, p := range report.Conflicts {
+		fmt.Fprintf(os.Stderr, "ERROR: Translation was modified since export: %s\n", p)
+	}
+	if len(report.Conflicts) > 0 {
+		return fmt.Errorf("%d translation(s) not imported, use --force to overwrite them", len(report.Conflicts))
+	}
+	return nil
+}
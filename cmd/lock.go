@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/lockfile"
+)
+
+var lockCommand = &cli.Command{
+	Name:  "lock",
+	Usage: "Manages lockfile with hashes of base files from which translations were made",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "update",
+			Usage:     "Records current content of base files for their translations (all or only for given base files)",
+			ArgsUsage: "languages [base-path...]",
+			Flags: concatFlags(diffFlags, []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "new-only",
+					Usage: "Add only translations not yet recorded in lockfile",
+					Value: false,
+				},
+			}),
+			Action: updateLock,
+		},
+	},
+}
+
+func updateLock(c *cli.Context) error {
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+
+	// resolve base paths
+	basepaths := a.bundle.BasePaths()
+	if c.NArg() > 1 {
+		if basepaths, err = resolveBasePaths(a.root, a.bundle, c.Args().Tail()); err != nil {
+			return err
+		}
+	}
+
+	path := a.resolve(c.String("lockfile"))
+	lock, err := lockfile.Read(path, a.root)
+	if err != nil {
+		return err
+	}
+	updated := 0
+	for _, basepath := range basepaths {
+		for _, lang := range a.langs {
+			if lang == a.baselang {
+				continue
+			}
+			changed, err := lock.Update(a.bundle, basepath, lang, c.Bool("new-only"))
+			if err != nil {
+				return err
+			}
+			if changed {
+				updated++
+			}
+		}
+	}
+	if err := lock.Write(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "INFO: %d translation(s) updated in lockfile: %s\n", updated, path)
+	return nil
+}
@@ -6,81 +6,140 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 
 	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/baseline"
 	"github.com/unravela/indiff/filesystem"
 	"github.com/unravela/indiff/git"
+	"github.com/unravela/indiff/lockfile"
 	"github.com/unravela/indiff/render"
 )
 
+// diffFlags are flags used by all commands calculating differences
+var diffFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "baselang",
+		Usage:       "Base language `CODE` against which diffs in other languages are tested",
+		Aliases:     []string{"b"},
+		DefaultText: "first provided language",
+	},
+	&cli.StringFlag{
+		Name: "glob",
+		Usage: trimMargin(
+			"Glob `PATTERN` " + `for language files identification.
+				|	You can use following placeholders in pattern: 
+				|		- %l: language code (required)
+				|		- %e: one or more supported file extensions
+				|	You can also use one of predefined patterns: ` + listPredefinedPatterns()),
+		Aliases: []string{"g"},
+		Value:   "SUB",
+	},
+	&cli.StringFlag{
+		Name:        "directory",
+		Usage:       "Working directory `PATH`",
+		Aliases:     []string{"d"},
+		Value:       ".",
+		DefaultText: "current dir",
+	},
+	&cli.StringSliceFlag{
+		Name:    "extensions",
+		Usage:   "File extensions substituted for %e in given glob (default: any)",
+		Aliases: []string{"e"},
+	},
+	&cli.BoolFlag{
+		Name:  "no-git",
+		Usage: "Do not use Git",
+		Value: false,
+	},
+	&cli.StringFlag{
+		Name:    "from-revision",
+		Usage:   "Revision in Git repository from which changes will be calculated (default: HEAD)",
+		Aliases: []string{"f"},
+	},
+	&cli.StringFlag{
+		Name:    "to-revision",
+		Usage:   "Revision in Git repository to which changes will be calculated (default: changes in worktree)",
+		Aliases: []string{"t"},
+	},
+	&cli.BoolFlag{
+		Name:    "absolute-paths",
+		Usage:   "Print absolute paths",
+		Value:   false,
+		Aliases: []string{"a"},
+	},
+	&cli.BoolFlag{
+		Name:    "show-diff",
+		Usage:   "Print diff for each modified file",
+		Value:   false,
+		Aliases: []string{"i"},
+	},
+	&cli.BoolFlag{
+		Name:  "check-mtime",
+		Usage: "Report translations older than their base files as possibly stale (useful with --no-git)",
+		Value: false,
+	},
+	&cli.StringFlag{
+		Name:  "hash-cache",
+		Usage: "Cache file `PATH` (relative to working directory) with content hashes used to refine --check-mtime",
+	},
+	&cli.StringFlag{
+		Name:  "lockfile",
+		Usage: "Lockfile `PATH` (relative to working directory) with hashes of base files from which translations were made",
+		Value: lockfile.DefaultPath,
+	},
+	&cli.StringFlag{
+		Name:  "acks",
+		Usage: "File `PATH` (relative to working directory) with acknowledged translations, which are not reported as modified only base",
+		Value: git.DefaultAcksPath,
+	},
+	&cli.StringFlag{
+		Name:  "baseline",
+		Usage: "Baseline file `PATH` (relative to working directory) with accepted differences, which are not reported again",
+		Value: baseline.DefaultPath,
+	},
+}
+
+// runFlags are flags used only by main command
+var runFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "format",
+		Usage: "Output `FORMAT`: plain, pretty (grouped and colored), html, markdown, github (Actions annotations), gitlab (Code Quality report), checkstyle, tap or template:PATH (Go text/template file)",
+		Value: "plain",
+	},
+	&cli.StringFlag{
+		Name:  "order",
+		Usage: "`ORDER` of differences in output: lang, kind or path",
+		Value: string(indiff.OrderLang),
+	},
+	&cli.IntFlag{
+		Name:  "max-size",
+		Usage: "Maximal `SIZE` of markdown output in bytes (e.g. to fit within comment limits), 0 for unlimited",
+		Value: 65000,
+	},
+	&cli.StringFlag{
+		Name:  "link-prefix",
+		Usage: "`URL` prepended to relative file paths in links of html output (e.g. address of repository browser)",
+	},
+	&cli.BoolFlag{
+		Name:  "estimate",
+		Usage: "Print count of words and characters which need to be (re)translated in each language (plain and html format)",
+		Value: false,
+	},
+}
+
 func main() {
 
 	app := &cli.App{
-		Name:      "indiff",
-		Usage:     "looks for missing transaltions",
-		ArgsUsage: "languages",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:        "baselang",
-				Usage:       "Base language `CODE` against which diffs in other languages are tested",
-				Aliases:     []string{"b"},
-				DefaultText: "first provided language",
-			},
-			&cli.StringFlag{
-				Name: "glob",
-				Usage: trimMargin(
-					"Glob `PATTERN` " + `for language files identification.
-					|	You can use following placeholders in pattern: 
-					|		- %l: language code (required)
-					|		- %e: one or more supported file extensions
-					|	You can also use one of predefined patterns: ` + listPredefinedPatterns()),
-				Aliases: []string{"g"},
-				Value:   "SUB",
-			},
-			&cli.StringFlag{
-				Name:        "directory",
-				Usage:       "Working directory `PATH`",
-				Aliases:     []string{"d"},
-				Value:       ".",
-				DefaultText: "current dir",
-			},
-			&cli.StringSliceFlag{
-				Name:    "extensions",
-				Usage:   "File extensions substituted for %e in given glob (default: any)",
-				Aliases: []string{"e"},
-			},
-			&cli.BoolFlag{
-				Name:  "no-git",
-				Usage: "Do not use Git",
-				Value: false,
-			},
-			&cli.StringFlag{
-				Name:    "from-revision",
-				Usage:   "Revision in Git repository from which changes will be calculated (default: HEAD)",
-				Aliases: []string{"f"},
-			},
-			&cli.StringFlag{
-				Name:    "to-revision",
-				Usage:   "Revision in Git repository to which changes will be calculated (default: changes in worktree)",
-				Aliases: []string{"t"},
-			},
-			&cli.BoolFlag{
-				Name:    "absolute-paths",
-				Usage:   "Print absolute paths",
-				Value:   false,
-				Aliases: []string{"a"},
-			},
-			&cli.BoolFlag{
-				Name:    "show-diff",
-				Usage:   "Print diff for each modified file",
-				Value:   false,
-				Aliases: []string{"i"},
-			},
-		},
+		Name:            "indiff",
+		Usage:           "looks for missing transaltions",
+		ArgsUsage:       "languages",
+		Flags:           concatFlags(diffFlags, runFlags, policyFlags),
+		Commands:        []*cli.Command{baselineCommand, statsCommand, exportCommand, importCommand, xliffCommand, scaffoldCommand, ackCommand, lockCommand, reviewCommand},
 		Writer:          os.Stderr,
 		HideHelpCommand: true,
 		Action:          run,
@@ -90,45 +149,149 @@ func main() {
 	err := app.Run(os.Args)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCodeError)
 	}
 }
 
+// analysis holds parsed arguments and differences calculated from them
+type analysis struct {
+	root          string
+	repoRoot      string
+	langs         []string
+	baselang      string
+	pattern       filesystem.Pattern
+	revisionRange *git.Range
+	revision      string
+	bundle        *indiff.Bundle
+	diffs         indiff.Diffs
+}
+
 func run(c *cli.Context) error {
-	// parse langs
-	rawlangs := c.Args().First()
-	// TODO: add autodiscovery of languages if one of predefined globs used
-	if rawlangs == "" {
-		cli.ShowAppHelp(c)
-		return fmt.Errorf("Missing required argument: languages")
+	a, err := analyze(c)
+	if err != nil {
+		return err
 	}
-	langs := strings.Split(rawlangs, ",")
-	if len(langs) < 2 {
+
+	// filter out differences accepted in baseline
+	diffs := a.diffs
+	isBaselineUsed := false
+	baselinePath := a.resolve(c.String("baseline"))
+	if _, err := os.Stat(baselinePath); err == nil {
+		b, err := baseline.Read(baselinePath, a.root)
+		if err != nil {
+			return err
+		}
+		isBaselineUsed = true
+		var removable []baseline.Entry
+		diffs, removable = b.Filter(diffs)
+		for _, e := range removable {
+			fmt.Fprintf(os.Stderr, "INFO: Fixed difference can be removed from baseline: %s\n", e)
+		}
+	}
+
+	// parse failure policy
+	policy, err := parsePolicy(c, isBaselineUsed)
+	if err != nil {
 		cli.ShowAppHelp(c)
-		return fmt.Errorf("Invalid argument: languages: provide minimally two language codes separated by comma")
+		return err
 	}
 
-	// parse baselang
-	baselang := c.String("baselang")
-	if baselang == "" {
-		baselang = langs[0]
-	} else if !contains(langs, baselang) {
+	// render
+	order, err := indiff.ParseOrder(c.String("order"))
+	if err != nil {
+		cli.ShowAppHelp(c)
+		return errors.Wrap(err, "Invalid argument: order")
+	}
+	relative := !c.Bool("absolute-paths")
+	var efforts []*indiff.Effort
+	if c.Bool("estimate") {
+		if format := c.String("format"); format != "plain" && format != "html" {
+			cli.ShowAppHelp(c)
+			return fmt.Errorf("Invalid argument: estimate: not supported by %s format", format)
+		}
+		if efforts, err = indiff.EstimateEffort(diffs); err != nil {
+			return errors.Wrap(err, "Unable to estimate translation effort")
+		}
+	}
+	var r render.Renderer
+	switch c.String("format") {
+	case "plain":
+		r = &render.Plain{RootPath: a.root, ShowRelativePaths: relative, ShowDiff: c.Bool("show-diff"), Order: order}
+	case "pretty":
+		r = &render.Pretty{RootPath: a.root, ShowRelativePaths: relative, ShowDiff: c.Bool("show-diff"), Color: render.ColorSupported(os.Stdout), Order: order}
+	case "html":
+		r = &render.HTML{
+			RootPath:          a.root,
+			ShowRelativePaths: relative,
+			ShowDiff:          c.Bool("show-diff"),
+			Efforts:           efforts,
+			LinkPrefix:        c.String("link-prefix"),
+			Order:             order,
+		}
+	case "markdown":
+		r = &render.Markdown{
+			RootPath:          a.root,
+			ShowRelativePaths: relative,
+			ShowDiff:          c.Bool("show-diff"),
+			Stats:             indiff.NewStats(a.bundle, a.langs, diffs),
+			MaxSize:           c.Int("max-size"),
+			Order:             order,
+		}
+	case "github":
+		r = &render.GitHub{RootPath: a.repoRoot, Order: order}
+	case "gitlab":
+		r = &render.GitLab{RootPath: a.repoRoot, Order: order}
+	case "checkstyle":
+		r = &render.Checkstyle{RootPath: a.root, Order: order}
+	case "tap":
+		r = &render.TAP{RootPath: a.root, Order: order}
+	default:
+		if path := strings.TrimPrefix(c.String("format"), "template:"); path != c.String("format") && path != "" {
+			r = &render.Template{
+				Path:     a.resolve(path),
+				RootPath: a.root,
+				BaseLang: a.baselang,
+				From:     a.revisionRange.Older,
+				To:       a.revisionRange.Newer,
+				Revision: a.revision,
+				Stats:    indiff.NewStats(a.bundle, a.langs, diffs),
+				Order:    order,
+			}
+			break
+		}
 		cli.ShowAppHelp(c)
-		return fmt.Errorf("Invalid argument: baselang: language '%s' not found", baselang)
+		return fmt.Errorf("Invalid argument: format: unknown format '%s'", c.String("format"))
+	}
+	if err := r.Render(os.Stdout, diffs); err != nil {
+		return errors.Wrapf(err, "Unable to render %s output", c.String("format"))
+	}
+
+	if p, ok := r.(*render.Plain); ok && efforts != nil {
+		p.RenderEffort(os.Stdout, efforts)
+	}
+
+	return checkPolicy(c, policy, a, diffs)
+}
+
+// analyze parses arguments and calculates differences
+func analyze(c *cli.Context) (*analysis, error) {
+	langs, baselang, err := parseLangs(c)
+	if err != nil {
+		return nil, err
 	}
 
 	// parse working direcotry
 	root, err := filepath.Abs(c.String("directory"))
 	if err != nil {
 		cli.ShowAppHelp(c)
-		return errors.Wrap(err, "Invalid argument: directory")
+		return nil, errors.Wrap(err, "Invalid argument: directory")
 	}
 
 	// parse pattern
 	pattern, err := filesystem.ParsePattern(c.String("glob"), c.StringSlice("extensions"))
 	if err != nil {
 		cli.ShowAppHelp(c)
-		return errors.Wrap(err, "Invalid argument: glob")
+		return nil, errors.Wrap(err, "Invalid argument: glob")
 	}
 
 	// parse revision range
@@ -138,38 +301,164 @@ func run(c *cli.Context) error {
 	}
 	isGitAllowed := !c.Bool("no-git")
 
-	// collect bundle
-	files := filesystem.NewFs(root, pattern).CollectFiles(langs)
-	bundle := indiff.NewBundle(baselang, files)
+	bundle, err := collectBundle(root, pattern, langs, baselang)
+	if err != nil {
+		return nil, err
+	}
 
 	// calculate basic diffs
-	diffs := indiff.NewBasic(langs).Diff(bundle)
+	diffs, err := indiff.NewBasic(langs).Diff(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	acks, err := git.ReadAcks(resolvePath(root, c.String("acks")), root)
+	if err != nil {
+		return nil, err
+	}
 
 	// calculate git based diffs
+	revision := ""
+	repoRoot := root
 	if isGitAllowed {
 		g, err := git.OpenGit(root, revisionRange)
 		if err == git.ErrRepoNotFound {
 			fmt.Fprintf(os.Stderr, "WARN: Git repository was not found. Check your path or use --no-git to hide this warning.\n")
-			return nil
 		} else if err != nil {
-			return errors.Wrap(err, "Error during opening Git repository")
+			return nil, errors.Wrap(err, "Error during opening Git repository")
+		} else {
+			repoRoot = g.Root()
+			g.UseAcks(acks)
+			gitDiffs, err := g.Diff(bundle)
+			if err != nil {
+				return nil, err
+			}
+			diffs = append(diffs, gitDiffs...)
+			if revision, err = g.Revision(); err != nil {
+				return nil, err
+			}
 		}
-		diffs = append(diffs, g.Diff(bundle)...)
 	}
 
-	// render
-	r := &render.Plain{
-		RootPath:          root,
-		ShowRelativePaths: !c.Bool("absolute-paths"),
-		ShowDiff:          c.Bool("show-diff"),
+	// calculate lockfile based diffs
+	lock, err := lockfile.Read(resolvePath(root, c.String("lockfile")), root)
+	if err != nil {
+		return nil, err
+	}
+	lockDiffs, err := lock.Diff(bundle)
+	if err != nil {
+		return nil, err
 	}
-	r.Render(os.Stdout, diffs)
+	if lockDiffs, err = acks.Filter(lockDiffs); err != nil {
+		return nil, err
+	}
+	diffs = append(diffs, lockDiffs...)
+
+	// calculate modification time based diffs
+	if c.Bool("check-mtime") || c.String("hash-cache") != "" {
+		cachePath := ""
+		if c.String("hash-cache") != "" {
+			cachePath = resolvePath(root, c.String("hash-cache"))
+		}
+		staleness, err := filesystem.NewStaleness(root, cachePath)
+		if err != nil {
+			return nil, err
+		}
+		staleDiffs, err := staleness.Diff(bundle)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, staleDiffs...)
+		if err := staleness.SaveCache(); err != nil {
+			return nil, err
+		}
+	}
+
+	// sort to get same output in each run
+	diffs = indiff.Sort(indiff.Unique(diffs), indiff.OrderLang)
+
+	return &analysis{
+		root:          root,
+		repoRoot:      repoRoot,
+		langs:         langs,
+		baselang:      baselang,
+		pattern:       pattern,
+		revisionRange: revisionRange,
+		revision:      revision,
+		bundle:        bundle,
+		diffs:         diffs,
+	}, nil
+}
+
+// resolve turns given path relative to working directory into absolute path
+func (a *analysis) resolve(path string) string {
+	return resolvePath(a.root, path)
+}
+
+// resolvePath turns given path relative to root into absolute path
+func resolvePath(root string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
 
-	return nil
+// collectBundle collects files in given languages from root directory
+func collectBundle(root string, pattern filesystem.Pattern, langs []string, baselang string) (*indiff.Bundle, error) {
+	fs, err := filesystem.NewFs(root, pattern)
+	if err != nil {
+		return nil, err
+	}
+	files, err := fs.CollectFiles(langs)
+	if err != nil {
+		return nil, err
+	}
+	return indiff.NewBundleWithKeys(baselang, files, fs.Keys), nil
+}
+
+// resolveBasePaths resolves given paths against root and checks that they are base files in bundle
+func resolveBasePaths(root string, bundle *indiff.Bundle, paths []string) ([]string, error) {
+	basepaths := bundle.BasePaths()
+	resolved := []string{}
+	for _, p := range paths {
+		abs := filepath.Clean(resolvePath(root, p))
+		i := sort.SearchStrings(basepaths, abs)
+		if i == len(basepaths) || basepaths[i] != abs {
+			return nil, fmt.Errorf("Invalid argument: base-path: %s is not base file in %s", p, root)
+		}
+		resolved = append(resolved, abs)
+	}
+	return resolved, nil
 }
 
 // helpers
 
+// parseLangs parses languages from first argument and base language from flag, which defaults to first language
+func parseLangs(c *cli.Context) (langs []string, baselang string, err error) {
+	// parse langs
+	rawlangs := c.Args().First()
+	// TODO: add autodiscovery of languages if one of predefined globs used
+	if rawlangs == "" {
+		cli.ShowAppHelp(c)
+		return nil, "", fmt.Errorf("Missing required argument: languages")
+	}
+	langs = strings.Split(rawlangs, ",")
+	if len(langs) < 2 {
+		cli.ShowAppHelp(c)
+		return nil, "", fmt.Errorf("Invalid argument: languages: provide minimally two language codes separated by comma")
+	}
+
+	// parse baselang
+	baselang = c.String("baselang")
+	if baselang == "" {
+		baselang = langs[0]
+	} else if !contains(langs, baselang) {
+		cli.ShowAppHelp(c)
+		return nil, "", fmt.Errorf("Invalid argument: baselang: language '%s' not found", baselang)
+	}
+	return langs, baselang, nil
+}
+
 func contains(xs []string, x string) bool {
 	for _, s := range xs {
 		if s == x {
@@ -179,6 +468,39 @@ func contains(xs []string, x string) bool {
 	return false
 }
 
+// concatFlags joins given flag lists into new one
+func concatFlags(lists ...[]cli.Flag) []cli.Flag {
+	flags := []cli.Flag{}
+	for _, l := range lists {
+		flags = append(flags, l...)
+	}
+	return flags
+}
+
+// pickFlags returns flags with given names from given list
+func pickFlags(flags []cli.Flag, names ...string) []cli.Flag {
+	picked := []cli.Flag{}
+	for _, f := range flags {
+		if contains(names, f.Names()[0]) {
+			picked = append(picked, f)
+		}
+	}
+	return picked
+}
+
+// splitList splits comma separated values in given list
+func splitList(xs []string) []string {
+	values := []string{}
+	for _, x := range xs {
+		for _, v := range strings.Split(x, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
 func listPredefinedPatterns() string {
 	var sb strings.Builder
 	for id, p := range filesystem.PredefinedPatterns {
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff"
+)
+
+// exitCodeError is exit code used when tool itself failed
+const exitCodeError = 1
+
+// policyFlags are flags configuring when found differences cause failure
+var policyFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  "fail-on",
+		Usage: "Comma separated `KINDS` of differences causing failure: " + listKinds() + " or all (default: none, all when baseline is used)",
+	},
+	&cli.StringSliceFlag{
+		Name:  "fail-langs",
+		Usage: "Comma separated `LANGUAGES` in which differences and coverage cause failure (default: all)",
+	},
+	&cli.Float64Flag{
+		Name:        "min-coverage",
+		Usage:       "Minimal `PERCENT` of base files translated in each language, lower coverage causes failure",
+		DefaultText: "not checked",
+	},
+	&cli.IntFlag{
+		Name:  "fail-exit-code",
+		Usage: "Exit `CODE` used when failure caused by found differences or coverage (tool errors always exit with 1)",
+		Value: 2,
+	},
+}
+
+// parsePolicy creates failure policy from flags
+func parsePolicy(c *cli.Context, isBaselineUsed bool) (*indiff.Policy, error) {
+	policy := &indiff.Policy{
+		Langs:       splitList(c.StringSlice("fail-langs")),
+		MinCoverage: c.Float64("min-coverage"),
+	}
+
+	rawkinds := splitList(c.StringSlice("fail-on"))
+	if len(rawkinds) == 0 && isBaselineUsed {
+		rawkinds = []string{"all"}
+	}
+	for _, k := range rawkinds {
+		if k == "all" {
+			policy.Kinds = indiff.Kinds
+			break
+		}
+		if !containsKind(indiff.Kinds, indiff.Kind(k)) {
+			return nil, fmt.Errorf("Invalid argument: fail-on: unknown kind '%s'", k)
+		}
+		if !containsKind(policy.Kinds, indiff.Kind(k)) {
+			policy.Kinds = append(policy.Kinds, indiff.Kind(k))
+		}
+	}
+
+	if code := c.Int("fail-exit-code"); code == 0 || code == exitCodeError {
+		return nil, fmt.Errorf("Invalid argument: fail-exit-code: code must differ from 0 and %d", exitCodeError)
+	}
+
+	return policy, nil
+}
+
+// checkPolicy returns error with configured exit code when policy is violated
+func checkPolicy(c *cli.Context, policy *indiff.Policy, a *analysis, diffs indiff.Diffs) error {
+	violations := policy.Violations(a.bundle, a.langs, diffs)
+	if len(violations) == 0 {
+		return nil
+	}
+	return cli.Exit("Failed: "+strings.Join(violations, ", "), c.Int("fail-exit-code"))
+}
+
+func containsKind(kinds []indiff.Kind, kind indiff.Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func listKinds() string {
+	kinds := make([]string, len(indiff.Kinds))
+	for i, k := range indiff.Kinds {
+		kinds[i] = string(k)
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff"
+)
+
+func TestParsePolicyDeduplicatesKinds(t *testing.T) {
+
+	// Given command with failure policy flags
+	var policy *indiff.Policy
+	app := &cli.App{
+		Flags: policyFlags,
+		Action: func(c *cli.Context) (err error) {
+			policy, err = parsePolicy(c, false)
+			return err
+		},
+	}
+
+	// When kinds are repeated in flag
+	if err := app.Run([]string{"indiff", "--fail-on", "missing,missing", "--fail-on", "possibly-stale,missing"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then each kind should be in policy only once
+	expected := []indiff.Kind{indiff.KindMissing, indiff.KindPossiblyStale}
+	if !reflect.DeepEqual(expected, policy.Kinds) {
+		t.Errorf("Unexpected kinds. Should be `%v` but was `%v`", expected, policy.Kinds)
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/git"
+	"github.com/unravela/indiff/render"
+)
+
+var reviewCommand = &cli.Command{
+	Name:      "review",
+	Usage:     "Walks through differences one by one and lets you resolve them",
+	ArgsUsage: "languages",
+	Flags: concatFlags(diffFlags, []cli.Flag{
+		&cli.StringFlag{
+			Name:  "log",
+			Usage: "Session log file `PATH` (relative to working directory) where all actions are appended",
+			Value: ".indiff-review.log",
+		},
+	}),
+	Action: review,
+}
+
+// reviewer holds state of interactive review session
+type reviewer struct {
+	a     *analysis
+	in    *bufio.Reader
+	out   io.Writer
+	log   io.Writer
+	acks  *git.Acks
+	paths *render.Plain
+	width int
+}
+
+func review(c *cli.Context) error {
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(a.resolve(c.String("log")), os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return errors.Wrap(err, "Unable to open session log")
+	}
+	defer logFile.Close()
+
+	acksPath := a.resolve(c.String("acks"))
+	acks, err := git.ReadAcks(acksPath, a.root)
+	if err != nil {
+		return err
+	}
+
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width <= 0 {
+		width = 160
+	}
+
+	r := &reviewer{
+		a:     a,
+		in:    bufio.NewReader(os.Stdin),
+		out:   os.Stdout,
+		log:   logFile,
+		acks:  acks,
+		paths: &render.Plain{RootPath: a.root, ShowRelativePaths: true},
+		width: width,
+	}
+	r.logf("session started: %d difference(s)", len(a.diffs))
+	err = r.run()
+	r.logf("session finished")
+
+	// keep acknowledgments made before failure
+	if writeErr := acks.Write(acksPath); err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+// run walks through all differences until all are processed or user quits
+func (r *reviewer) run() error {
+	for i, d := range r.a.diffs {
+		fmt.Fprintf(r.out, "\n[%d/%d] ", i+1, len(r.a.diffs))
+		if err := r.paths.Render(r.out, indiff.Diffs{d}); err != nil {
+			return err
+		}
+		r.show(d)
+
+		for done := false; !done; {
+			action, err := r.ask(d)
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if !contains(actionsFor(d), action) {
+				fmt.Fprintf(r.out, "Unknown action: %s\n", action)
+				continue
+			}
+			switch action {
+			case "e":
+				err = r.edit(d)
+			case "a":
+				err = r.acks.Add(d.Base().Path, d.Lang())
+				done = true
+			case "c":
+				err = r.scaffold(d)
+			case "s":
+				done = true
+			case "q":
+				r.logf("quit: %s: %s", d.Lang(), r.relative(d.Base()))
+				return nil
+			}
+			if err != nil {
+				fmt.Fprintf(r.out, "ERROR: %s\n", err)
+				r.logf("failed %s: %s: %s: %s", actionNames[action], d.Lang(), r.relative(d.Base()), err)
+				continue
+			}
+			r.logf("%s: %s: %s", actionNames[action], d.Lang(), r.relative(d.Base()))
+		}
+	}
+	return nil
+}
+
+var actionNames = map[string]string{
+	"e": "edited",
+	"a": "acknowledged",
+	"c": "scaffolded",
+	"s": "skipped",
+}
+
+var actionLabels = map[string]string{
+	"e": "[e]dit",
+	"a": "[a]ck",
+	"c": "[c]reate",
+	"s": "[s]kip",
+	"q": "[q]uit",
+}
+
+// actionsFor returns actions offered for given difference. Only modified base and possibly stale translation
+// can be acknowledged as only these differences are dropped by acknowledgments.
+func actionsFor(d indiff.Diff) []string {
+	switch d.Kind() {
+	case indiff.KindMissing:
+		return []string{"c", "e", "s", "q"}
+	case indiff.KindModifiedBase, indiff.KindPossiblyStale:
+		return []string{"e", "a", "s", "q"}
+	default:
+		return []string{"e", "s", "q"}
+	}
+}
+
+// show prints base patch (or whole base file for missing translation) and current translation side by side
+func (r *reviewer) show(d indiff.Diff) {
+	left := ""
+	switch diff := d.(type) {
+	case *indiff.ModifiedBase:
+		left = patchText(diff.BasePatch())
+	case *indiff.ModifiedBoth:
+		left = patchText(diff.BasePatch())
+	default:
+		content, _ := ioutil.ReadFile(d.Base().Path)
+		left = string(content)
+	}
+	right := "<missing>"
+	if t := r.translation(d); t != "" {
+		if content, err := ioutil.ReadFile(t); err == nil {
+			right = string(content)
+		}
+	}
+	render.SideBySide(r.out, left, right, r.width)
+}
+
+// ask reads action from user
+func (r *reviewer) ask(d indiff.Diff) (string, error) {
+	options := []string{}
+	for _, a := range actionsFor(d) {
+		options = append(options, actionLabels[a])
+	}
+	fmt.Fprintf(r.out, "Action %s: ", strings.Join(options, ", "))
+	line, err := r.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(line)), nil
+}
+
+// edit opens translation in $EDITOR
+func (r *reviewer) edit(d indiff.Diff) error {
+	t := r.translation(d)
+	if _, err := os.Stat(t); t == "" || err != nil {
+		return fmt.Errorf("Translation does not exist, create it first")
+	}
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	cmd := exec.Command(editor, t)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// scaffold creates missing translation as copy of base file
+func (r *reviewer) scaffold(d indiff.Diff) error {
+	s := &filesystem.Scaffold{
+		Root:     r.a.root,
+		Pattern:  r.a.pattern,
+		BaseLang: r.a.baselang,
+		Strategy: filesystem.StubCopy,
+	}
+	path, err := s.CreateFile(d.Base(), d.Lang())
+	if err == filesystem.ErrTranslationExists {
+		return errors.Wrap(err, r.relative(indiff.NewFile(path, d.Lang())))
+	} else if err == nil {
+		fmt.Fprintf(r.out, "created: %s\n", r.relative(indiff.NewFile(path, d.Lang())))
+	}
+	return err
+}
+
+// patchText returns given patch in unified format or description of error when patch could not be loaded
+func patchText(patch *indiff.Patch, err error) string {
+	if err != nil {
+		return fmt.Sprintf("<%s>", err)
+	}
+	return render.Unified(patch)
+}
+
+// translation returns path to existing or expected translation file
+func (r *reviewer) translation(d indiff.Diff) string {
+	if t := d.Translation(); t != nil {
+		return t.Path
+	}
+	rel, err := filepath.Rel(r.a.root, d.Base().Path)
+	if err != nil {
+		return ""
+	}
+	p, ok, err := r.a.pattern.TranslationPath(rel, r.a.baselang, d.Lang())
+	if err != nil || !ok {
+		return ""
+	}
+	return filepath.Join(r.a.root, p)
+}
+
+// relative returns path of given file relative to working directory
+func (r *reviewer) relative(f *indiff.File) string {
+	rel, err := filepath.Rel(r.a.root, f.Path)
+	if err != nil {
+		return f.Path
+	}
+	return rel
+}
+
+// logf appends line to session log
+func (r *reviewer) logf(format string, args ...interface{}) {
+	fmt.Fprintf(r.log, "%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/git"
+	"github.com/unravela/indiff/render"
+)
+
+func TestReviewAck(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		diff  func(base *indiff.File, translation *indiff.File) indiff.Diff
+		acked bool
+	}{
+		{"modified-base", func(b *indiff.File, t *indiff.File) indiff.Diff {
+			return indiff.NewModifiedBase(b.Modified(nil), t)
+		}, true},
+		{"modified-both", func(b *indiff.File, t *indiff.File) indiff.Diff {
+			return indiff.NewModifiedBoth(b.Modified(nil), t.Modified(nil))
+		}, false},
+		{"possibly-stale", func(b *indiff.File, t *indiff.File) indiff.Diff {
+			return indiff.NewPossiblyStale(b, t)
+		}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Given reviewer of single difference
+			root := tempRoot(t)
+			defer os.RemoveAll(root)
+			d := test.diff(indiff.NewFile(filepath.Join(root, "en", "first.md"), "en"), indiff.NewFile(filepath.Join(root, "de", "first.md"), "de"))
+			r, out := newTestReviewer(t, root, d, "a\ns\n")
+
+			// When user tries to acknowledge it
+			if err := r.run(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Then only modified base and possibly stale translation should be acknowledged
+			remaining, err := r.acks.Filter(indiff.Diffs{indiff.NewPossiblyStale(d.Base(), d.Translation())})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if acked := len(remaining) == 0; acked != test.acked {
+				t.Errorf("Unexpected acknowledgment. Should be `%t` but was `%t`", test.acked, acked)
+			}
+			if offered := strings.Contains(out.String(), "[a]ck"); offered != test.acked {
+				t.Errorf("Unexpected offer of ack action. Should be `%t` but was `%t` in `%s`", test.acked, offered, out)
+			}
+		})
+	}
+}
+
+func TestReviewQuit(t *testing.T) {
+
+	// Given reviewer of missing translation
+	root := tempRoot(t)
+	defer os.RemoveAll(root)
+	d := indiff.NewMissing(indiff.NewFile(filepath.Join(root, "en", "first.md"), "en"), "sk")
+	r, out := newTestReviewer(t, root, d, "q\n")
+
+	// When user quits
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then base file should be shown next to missing translation with create action offered
+	expected := "\n[1/1] sk: missing translation of: en/first.md\n# First    | <missing>\nAction [c]reate, [e]dit, [s]kip, [q]uit: "
+	if out.String() != expected {
+		t.Errorf("Unexpected output. Should be `%q` but was `%q`", expected, out)
+	}
+}
+
+// helpers
+
+// tempRoot creates root directory with base file en/first.md and its translation de/first.md
+func tempRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{"en/first.md": "# First", "de/first.md": "# Erste"} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// newTestReviewer creates reviewer of given difference reading given input and returns buffer with its output
+func newTestReviewer(t *testing.T, root string, d indiff.Diff, input string) (*reviewer, *bytes.Buffer) {
+	acks, err := git.ReadAcks(filepath.Join(root, git.DefaultAcksPath), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	return &reviewer{
+		a: &analysis{
+			root:     root,
+			baselang: "en",
+			pattern:  filesystem.MustParsePattern("SUB", nil),
+			diffs:    indiff.Diffs{d},
+		},
+		in:    bufio.NewReader(strings.NewReader(input)),
+		out:   out,
+		log:   ioutil.Discard,
+		acks:  acks,
+		paths: &render.Plain{RootPath: root, ShowRelativePaths: true},
+		width: 20,
+	}, out
+}
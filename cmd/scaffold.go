@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/filesystem"
+)
+
+var scaffoldCommand = &cli.Command{
+	Name:      "scaffold",
+	Usage:     "Creates stub file for each missing translation",
+	ArgsUsage: "languages",
+	Flags: concatFlags(diffFlags, []cli.Flag{
+		&cli.StringFlag{
+			Name:    "stub",
+			Usage:   "`STRATEGY` of stub content: " + listStubStrategies(),
+			Aliases: []string{"s"},
+			Value:   string(filesystem.StubCopy),
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only print paths of files which would be created",
+			Value: false,
+		},
+	}),
+	Action: scaffold,
+}
+
+func scaffold(c *cli.Context) error {
+	strategy := filesystem.StubStrategy(c.String("stub"))
+	if !isKnownStubStrategy(strategy) {
+		cli.ShowSubcommandHelp(c)
+		return fmt.Errorf("Invalid argument: stub: unknown strategy '%s'", strategy)
+	}
+
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+
+	s := &filesystem.Scaffold{
+		Root:     a.root,
+		Pattern:  a.pattern,
+		BaseLang: a.baselang,
+		Strategy: strategy,
+		DryRun:   c.Bool("dry-run"),
+	}
+	created, skipped, err := s.Create(a.diffs)
+	for _, p := range created {
+		if s.DryRun {
+			fmt.Fprintf(os.Stdout, "would create: %s\n", relativeTo(a.root, p))
+		} else {
+			fmt.Fprintf(os.Stdout, "created: %s\n", relativeTo(a.root, p))
+		}
+	}
+	for _, p := range skipped {
+		fmt.Fprintf(os.Stderr, "WARN: Skipped existing file: %s\n", relativeTo(a.root, p))
+	}
+	return err
+}
+
+// relativeTo returns given path relative to root or unchanged path when it is not possible
+func relativeTo(root string, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func isKnownStubStrategy(strategy filesystem.StubStrategy) bool {
+	for _, s := range filesystem.StubStrategies {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+func listStubStrategies() string {
+	strategies := make([]string, len(filesystem.StubStrategies))
+	for i, s := range filesystem.StubStrategies {
+		strategies[i] = string(s)
+	}
+	return strings.Join(strategies, ", ")
+}
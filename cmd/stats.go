@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/render"
+)
+
+var statsCommand = &cli.Command{
+	Name:      "stats",
+	Usage:     "Prints translation coverage statistics for each language",
+	ArgsUsage: "languages",
+	Flags: concatFlags(diffFlags, []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "words",
+			Usage:   "Count words in files of each language",
+			Value:   false,
+			Aliases: []string{"w"},
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Output `FORMAT`: plain or json",
+			Value: "plain",
+		},
+	}),
+	Action: stats,
+}
+
+func stats(c *cli.Context) error {
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+
+	stats := indiff.NewStats(a.bundle, a.langs, a.diffs)
+	if c.Bool("words") {
+		if err := indiff.CountWords(a.bundle, stats); err != nil {
+			return err
+		}
+	}
+
+	switch c.String("format") {
+	case "plain":
+		(&render.Plain{}).RenderStats(os.Stdout, stats)
+	case "json":
+		return (&render.StatsJSON{}).RenderStats(os.Stdout, stats)
+	default:
+		return fmt.Errorf("Invalid argument: format: unknown format '%s'", c.String("format"))
+	}
+	return nil
+}
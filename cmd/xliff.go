@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/xliff"
+)
+
+var xliffCommand = &cli.Command{
+	Name:  "xliff",
+	Usage: "Converts differences to XLIFF 2.0 documents for CAT tools and back",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Writes one XLIFF document per language with missing and modified base files",
+			ArgsUsage: "languages",
+			Flags: concatFlags(diffFlags, []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Usage:   "Output directory `PATH` where documents are created",
+					Aliases: []string{"o"},
+					Value:   "indiff-xliff",
+				},
+			}),
+			Action: exportXliff,
+		},
+		{
+			Name:      "import",
+			Usage:     "Rebuilds translated files from XLIFF documents",
+			ArgsUsage: "languages documents...",
+			Flags: concatFlags(pickFlags(diffFlags, "baselang", "glob", "directory", "extensions"), []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Overwrite translations modified since export",
+					Value: false,
+				},
+			}),
+			Action: importXliff,
+		},
+	},
+}
+
+func exportXliff(c *cli.Context) error {
+	a, err := analyze(c)
+	if err != nil {
+		return err
+	}
+
+	out := c.String("output")
+	if err := os.MkdirAll(out, os.FileMode(0755)); err != nil {
+		return errors.Wrapf(err, "Unable to create output directory: %s", out)
+	}
+
+	converter := &xliff.Converter{Root: a.root, BaseLang: a.baselang}
+	for _, lang := range a.langs {
+		if lang == a.baselang {
+			continue
+		}
+		doc, err := converter.Convert(lang, a.diffs)
+		if err != nil {
+			return err
+		}
+		if len(doc.Files) == 0 {
+			continue
+		}
+		path := filepath.Join(out, lang+".xlf")
+		f, err := os.Create(path)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to create file: %s", path)
+		}
+		err = doc.Write(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "INFO: XLIFF document created: %s\n", path)
+	}
+	return nil
+}
+
+func importXliff(c *cli.Context) error {
+	langs, baselang, err := parseLangs(c)
+	if err != nil {
+		return err
+	}
+	if c.NArg() < 2 {
+		cli.ShowSubcommandHelp(c)
+		return fmt.Errorf("Missing required argument: documents")
+	}
+	root, err := filepath.Abs(c.String("directory"))
+	if err != nil {
+		return errors.Wrap(err, "Invalid argument: directory")
+	}
+	pattern, err := filesystem.ParsePattern(c.String("glob"), c.StringSlice("extensions"))
+	if err != nil {
+		return errors.Wrap(err, "Invalid argument: glob")
+	}
+
+	i := &xliff.Import{
+		Root:     root,
+		Pattern:  pattern,
+		BaseLang: baselang,
+		Langs:    langs,
+		Force:    c.Bool("force"),
+	}
+	conflicts := 0
+	for _, path := range c.Args().Tail() {
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to open XLIFF document: %s", path)
+		}
+		doc, err := xliff.Read(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "Unable to read XLIFF document: %s", path)
+		}
+		report, err := i.Write(doc)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to import XLIFF document: %s", path)
+		}
+
+		// print report
+		for _, p := range report.Skipped {
+			fmt.Fprintf(os.Stderr, "WARN: Skipped file without any translated segment: %s\n", p)
+		}
+		for _, p := range report.Imported {
+			fmt.Fprintf(os.Stderr, "INFO: Translation rebuilt: %s\n", p)
+			if untranslated := report.Untranslated[p]; untranslated > 0 {
+				fmt.Fprintf(os.Stderr, "WARN: %d segment(s) without translation in: %s\n", untranslated, p)
+			}
+		}
+		for _, p := range report.Conflicts {
+			fmt.Fprintf(os.Stderr, "ERROR: Translation was modified since export: %s\n", p)
+		}
+		conflicts += len(report.Conflicts)
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%d translation(s) not imported, use --force to overwrite them", conflicts)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package indiff
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 // Diffs is collection of multiple differences
 type Diffs = []Diff
@@ -13,12 +16,54 @@ type Diff interface {
 	Translation() *File
 	// Lang is language in which this difference occurs (usually defined by translation file)
 	Lang() string
+	// Kind identifies type of difference
+	Kind() Kind
+}
+
+// Kind is short name identifying type of difference
+type Kind string
+
+// Kinds of differences known to indiff
+const (
+	KindMissing       Kind = "missing"
+	KindModifiedBase  Kind = "modified-base"
+	KindModifiedBoth  Kind = "modified-both"
+	KindPossiblyStale Kind = "possibly-stale"
+)
+
+// Kinds lists all kinds of differences
+var Kinds = []Kind{KindMissing, KindModifiedBase, KindModifiedBoth, KindPossiblyStale}
+
+// uniquePrecedence orders kinds of differences reported for same translation from the most specific one
+var uniquePrecedence = map[Kind]int{KindModifiedBoth: 0, KindModifiedBase: 1, KindPossiblyStale: 2, KindMissing: 3}
+
+// Unique removes repeated differences for same base file and language (e.g. found by multiple diff tools).
+// Only the most specific difference is kept (modified both, modified base, possibly stale, missing) on position
+// of first difference for same translation.
+func Unique(diffs Diffs) Diffs {
+	type key struct {
+		lang string
+		path string
+	}
+	index := map[key]int{}
+	unique := Diffs{}
+	for _, d := range diffs {
+		k := key{lang: d.Lang(), path: d.Base().Path}
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(unique)
+			unique = append(unique, d)
+		} else if uniquePrecedence[d.Kind()] < uniquePrecedence[unique[i].Kind()] {
+			unique[i] = d
+		}
+	}
+	return unique
 }
 
 // DiffTool represent tool for calculating differences
 type DiffTool interface {
-	// Diff calculates differences
-	Diff(bundle *Bundle) []Diff
+	// Diff calculates differences, it fails when files or repository needed for comparison can't be read
+	Diff(bundle *Bundle) ([]Diff, error)
 }
 
 // Missing says that there is no translation for base file in specified language
@@ -47,26 +92,52 @@ func (m *Missing) Lang() string {
 	return m.lang
 }
 
+// Kind returns KindMissing
+func (m *Missing) Kind() Kind {
+	return KindMissing
+}
+
 func (m *Missing) String() string {
 	return fmt.Sprintf("Missing{ base: %s, lang: %s }", m.base, m.lang)
 }
 
-// Modification holds modified file changes made to this file
+// Modification holds modified file changes made to this file.
+// Changes can be loaded lazily, they are loaded at most once when they are requested for the first time.
 type Modification struct {
 	file  *File
-	patch string // TODO: use some structured type instead of string to be able to control output
+	patch *Patch
+	err   error
+	load  func() (*Patch, error)
+	once  sync.Once
 }
 
-// NewModification turns File to Modification (modified file) with changes in given patch
-func NewModification(file *File, patch string) *Modification {
+// NewModification turns File to Modification (modified file) with changes in given patch, nil patch means unknown changes
+func NewModification(file *File, patch *Patch) *Modification {
 	return &Modification{file: file, patch: patch}
 }
 
+// NewLazyModification turns File to Modification with changes which are loaded by given function when they are needed.
+// Error returned by load function is returned by every request for changes.
+func NewLazyModification(file *File, load func() (*Patch, error)) *Modification {
+	return &Modification{file: file, load: load}
+}
+
 // Modified turns this file to Modification with changes in given patch
-func (f *File) Modified(patch string) *Modification {
+func (f *File) Modified(patch *Patch) *Modification {
 	return NewModification(f, patch)
 }
 
+// Patch returns changes made to file, they are loaded when needed
+func (m *Modification) Patch() (*Patch, error) {
+	m.once.Do(func() {
+		if m.load != nil {
+			m.patch, m.err = m.load()
+			m.load = nil
+		}
+	})
+	return m.patch, m.err
+}
+
 // ModifiedBase says that base file was modified but it's translation was not
 type ModifiedBase struct {
 	base        *Modification
@@ -84,9 +155,9 @@ func (m *ModifiedBase) Base() *File {
 	return m.base.file
 }
 
-// BasePatch returns text representation of changes made to file in base language
-func (m *ModifiedBase) BasePatch() string {
-	return m.base.patch
+// BasePatch returns changes made to file in base language, it's nil when changes are not known
+func (m *ModifiedBase) BasePatch() (*Patch, error) {
+	return m.base.Patch()
 }
 
 // Translation points to file which equivalent in base language was modified
@@ -99,6 +170,11 @@ func (m *ModifiedBase) Lang() string {
 	return m.translation.Lang
 }
 
+// Kind returns KindModifiedBase
+func (m *ModifiedBase) Kind() Kind {
+	return KindModifiedBase
+}
+
 func (m *ModifiedBase) String() string {
 	return fmt.Sprintf("ModifiedBase{ base: %s, translation: %s }", m.base.file, m.translation)
 }
@@ -120,14 +196,14 @@ func (m *ModifiedBoth) Base() *File {
 	return m.base.file
 }
 
-// BasePatch returns text representation of changes made to file in base language
-func (m *ModifiedBoth) BasePatch() string {
-	return m.base.patch
+// BasePatch returns changes made to file in base language, it's nil when changes are not known
+func (m *ModifiedBoth) BasePatch() (*Patch, error) {
+	return m.base.Patch()
 }
 
-// TranslationPatch returns text representation of changes made to translation file
-func (m *ModifiedBoth) TranslationPatch() string {
-	return m.translation.patch
+// TranslationPatch returns changes made to translation file, it's nil when changes are not known
+func (m *ModifiedBoth) TranslationPatch() (*Patch, error) {
+	return m.translation.Patch()
 }
 
 // Translation points to translation file which was modified
@@ -140,6 +216,46 @@ func (m *ModifiedBoth) Lang() string {
 	return m.translation.file.Lang
 }
 
+// Kind returns KindModifiedBoth
+func (m *ModifiedBoth) Kind() Kind {
+	return KindModifiedBoth
+}
+
 func (m *ModifiedBoth) String() string {
 	return fmt.Sprintf("ModifiedBoth{ base: %s, translation: %s }", m.base.file, m.translation.file)
 }
+
+// PossiblyStale says that base file seems to be changed after its translation, but exact changes are not known
+type PossiblyStale struct {
+	base        *File
+	translation *File
+}
+
+// NewPossiblyStale creates new PossiblyStale file difference
+func NewPossiblyStale(base *File, translation *File) *PossiblyStale {
+	return &PossiblyStale{base: base, translation: translation}
+}
+
+// Base points to file in base language which seems to be changed
+func (p *PossiblyStale) Base() *File {
+	return p.base
+}
+
+// Translation points to translation file which seems to be outdated
+func (p *PossiblyStale) Translation() *File {
+	return p.translation
+}
+
+// Lang is language of translation file
+func (p *PossiblyStale) Lang() string {
+	return p.translation.Lang
+}
+
+// Kind returns KindPossiblyStale
+func (p *PossiblyStale) Kind() Kind {
+	return KindPossiblyStale
+}
+
+func (p *PossiblyStale) String() string {
+	return fmt.Sprintf("PossiblyStale{ base: %s, translation: %s }", p.base, p.translation)
+}
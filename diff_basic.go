@@ -11,8 +11,8 @@ func NewBasic(langs []string) *Basic {
 	return &Basic{langs: langs}
 }
 
-// Diff calculates the differences in given bundle
-func (b *Basic) Diff(bundle *Bundle) []Diff {
+// Diff calculates the differences in given bundle, it never fails
+func (b *Basic) Diff(bundle *Bundle) ([]Diff, error) {
 	diffs := []Diff{}
 	for _, lang := range b.langs {
 		if lang == bundle.BaseLang() {
@@ -25,5 +25,5 @@ func (b *Basic) Diff(bundle *Bundle) []Diff {
 			}
 		}
 	}
-	return diffs
+	return diffs, nil
 }
@@ -20,7 +20,10 @@ func TestBasicDiff(t *testing.T) {
 	// Given expected difference
 
 	// When diffs are calculated
-	diffs := diffTool.Diff(bundle)
+	diffs, err := diffTool.Diff(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	// Then diffs should contain one missing file
 	var missing Diff = NewMissing(NewFile("en/second.md", "en"), "de")
@@ -0,0 +1,89 @@
+package indiff
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestLazyModification(t *testing.T) {
+
+	// Given modified base with lazily loaded patch shared by two translations
+	loads := 0
+	patch := &Patch{Hunks: []*Hunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1}}}
+	base := NewLazyModification(NewFile("en/first.md", "en"), func() (*Patch, error) {
+		loads++
+		return patch, nil
+	})
+	de := NewModifiedBase(base, NewFile("de/first.md", "de"))
+	sk := NewModifiedBoth(base, NewFile("sk/first.md", "sk").Modified(nil))
+
+	// Then patch should not be loaded until it is requested
+	if de.Base().Path != "en/first.md" || loads != 0 {
+		t.Errorf("Unexpected count of loads before patch is requested. Should be `%d` but was `%d`", 0, loads)
+	}
+
+	// Then patch should be loaded only once
+	for _, d := range []interface{ BasePatch() (*Patch, error) }{de, sk, de} {
+		if p, err := d.BasePatch(); p != patch || err != nil {
+			t.Errorf("Unexpected patch. Should be `%v` but was `%v` (error: %v)", patch, p, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("Unexpected count of loads. Should be `%d` but was `%d`", 1, loads)
+	}
+	if p, _ := sk.TranslationPatch(); p != nil {
+		t.Errorf("Unexpected translation patch. Should be nil but was `%v`", p)
+	}
+}
+
+func TestLazyModificationFailure(t *testing.T) {
+
+	// Given modification which patch can't be loaded
+	loads := 0
+	failure := &IOError{Path: "en/first.md", Err: os.ErrNotExist}
+	base := NewLazyModification(NewFile("en/first.md", "en"), func() (*Patch, error) {
+		loads++
+		return nil, failure
+	})
+	diff := NewModifiedBase(base, NewFile("de/first.md", "de"))
+
+	// When patch is requested repeatedly
+	diff.BasePatch()
+	patch, err := diff.BasePatch()
+
+	// Then error should be returned every time without repeated load
+	if patch != nil || err != failure {
+		t.Errorf("Unexpected result. Should be `%v` but was `%v` (patch: %v)", failure, err, patch)
+	}
+	if loads != 1 {
+		t.Errorf("Unexpected count of loads. Should be `%d` but was `%d`", 1, loads)
+	}
+
+	// Then error should be recognized as IOError
+	var ioErr *IOError
+	if !errors.As(errors.Wrap(err, "Unable to render"), &ioErr) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Unexpected error type. Should be `*IOError` but was `%T`", err)
+	}
+}
+
+func TestUnique(t *testing.T) {
+
+	// Given translations reported by multiple diff tools with different kinds
+	first, second := NewFile("en/first.md", "en"), NewFile("en/second.md", "en")
+	stale := NewPossiblyStale(first, NewFile("de/first.md", "de"))
+	modified := NewModifiedBase(first.Modified(nil), NewFile("de/first.md", "de"))
+	missing := NewMissing(second, "de")
+	otherLang := NewPossiblyStale(first, NewFile("sk/first.md", "sk"))
+
+	// When differences are deduplicated
+	unique := Unique(Diffs{stale, missing, modified, otherLang, NewMissing(second, "de")})
+
+	// Then only the most specific difference should be kept for each translation on position of first one
+	expected := Diffs{modified, missing, otherLang}
+	if !reflect.DeepEqual(expected, unique) {
+		t.Errorf("Unexpected differences. Should be `%s` but was `%s`", expected, unique)
+	}
+}
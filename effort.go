@@ -0,0 +1,163 @@
+package indiff
+
+import (
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Effort holds amount of text in base language which needs to be (re)translated to some language
+type Effort struct {
+	// Lang is language to which text needs to be translated
+	Lang string `json:"lang"`
+	// Words is count of words
+	Words int `json:"words"`
+	// Characters is count of characters in words (without whitespaces)
+	Characters int `json:"characters"`
+}
+
+// EstimateEffort calculates amount of text to translate for each language in given diffs.
+// Whole base file is counted for Missing translation and only added or changed lines of base patch for ModifiedBase.
+// Changes of PossiblyStale base file are unknown, so whole base file is counted too.
+// Front matter, code blocks and markup are not counted, code blocks are recognized in whole base file.
+func EstimateEffort(diffs Diffs) ([]*Effort, error) {
+	efforts := map[string]*Effort{}
+	for _, d := range diffs {
+		var text string
+		switch diff := d.(type) {
+		case *Missing, *PossiblyStale:
+			content, err := readFile(diff.Base().Path)
+			if err != nil {
+				return nil, err
+			}
+			text = content
+		case *ModifiedBase:
+			patch, err := diff.BasePatch()
+			if err != nil {
+				return nil, err
+			}
+			if patch.IsEmpty() {
+				break
+			}
+			content, err := readFile(diff.Base().Path)
+			if err != nil {
+				return nil, err
+			}
+			text = addedLines(patch, untranslatableLines(content))
+		default:
+			continue
+		}
+
+		e := efforts[d.Lang()]
+		if e == nil {
+			e = &Effort{Lang: d.Lang()}
+			efforts[d.Lang()] = e
+		}
+		words, characters := countWords(text)
+		e.Words += words
+		e.Characters += characters
+	}
+
+	result := make([]*Effort, 0, len(efforts))
+	for _, e := range efforts {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Lang < result[j].Lang })
+	return result, nil
+}
+
+// addedLines returns text of lines added by given patch except lines with given numbers
+func addedLines(patch *Patch, excluded map[int]bool) string {
+	b := &strings.Builder{}
+	for _, line := range patch.Lines(LineAdded) {
+		if excluded[line.NewNumber] {
+			continue
+		}
+		b.WriteString(line.Content)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// untranslatableLines returns numbers of lines (starting at 1) of given text which are part of front matter or code block
+func untranslatableLines(text string) map[int]bool {
+	lines := map[int]bool{}
+	for _, re := range []*regexp.Regexp{frontMatterRegexp, codeBlockRegexp} {
+		for _, m := range re.FindAllStringIndex(text, -1) {
+			first := strings.Count(text[:m[0]], "\n") + 1
+			last := first + strings.Count(text[m[0]:m[1]], "\n")
+			for n := first; n <= last; n++ {
+				lines[n] = true
+			}
+		}
+	}
+	return lines
+}
+
+// countWords returns count of translatable words in given text and count of characters in them (without whitespaces).
+// Front matter, code blocks and markup are not counted. It is shared by statistics and effort estimation.
+func countWords(text string) (words int, characters int) {
+	fields := strings.Fields(translatableText(text))
+	for _, w := range fields {
+		characters += len([]rune(w))
+	}
+	return len(fields), characters
+}
+
+// readFile returns content of file on given path
+func readFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", &IOError{Path: path, Err: err}
+	}
+	return string(content), nil
+}
+
+var (
+	frontMatterRegexp = regexp.MustCompile(`(?s)\A(---|\+\+\+)\r?\n.*?\r?\n(---|\+\+\+)[ \t]*(\r?\n|\z)`)
+	codeBlockRegexp   = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)[^\\n]*$")
+	inlineCodeRegexp  = regexp.MustCompile("`[^`\\n]*`")
+	htmlRegexp        = regexp.MustCompile(`(?s)<!--.*?-->|<[^>\n]+>`)
+	linkRegexp        = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	listMarkerRegexp  = regexp.MustCompile(`(?m)^\s*([-*+]|\d+[.)])\s+`)
+)
+
+// SplitFrontMatter splits given content to YAML or TOML front matter (including delimiters) and rest of content.
+// Front matter is empty when content doesn't start with it.
+func SplitFrontMatter(content string) (frontMatter string, body string) {
+	loc := frontMatterRegexp.FindStringIndex(content)
+	if loc == nil {
+		return "", content
+	}
+	return content[:loc[1]], content[loc[1]:]
+}
+
+// translatableText removes front matter, code blocks and markup from given text
+func translatableText(text string) string {
+	_, text = SplitFrontMatter(text)
+	text = codeBlockRegexp.ReplaceAllString(text, "")
+	text = inlineCodeRegexp.ReplaceAllString(text, "")
+	text = htmlRegexp.ReplaceAllString(text, " ")
+	text = linkRegexp.ReplaceAllString(text, "$1")
+	text = listMarkerRegexp.ReplaceAllString(text, "")
+
+	// remove words without any letter or digit (e.g. `#`, `|` or `---`)
+	words := strings.Fields(text)
+	kept := words[:0]
+	for _, w := range words {
+		if strings.IndexFunc(w, isLetterOrDigit) >= 0 {
+			kept = append(kept, strings.TrimFunc(w, isMarkup))
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isMarkup(r rune) bool {
+	return strings.ContainsRune("*_~#>|", r)
+}
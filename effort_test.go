@@ -0,0 +1,132 @@
+package indiff_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/internal/patchtest"
+)
+
+func TestEstimateEffort(t *testing.T) {
+
+	// Given modified base file with added markdown content, code block and removed line
+	patch := "@@ -1,2 +1,8 @@\n" +
+		" # Title\n" +
+		"-Old sentence\n" +
+		"+## New *section*\n" +
+		"+\n" +
+		"+- See [the docs](http://example.com) <br>\n" +
+		"+```go\n" +
+		"+fmt.Println(\"not counted\")\n" +
+		"+```\n" +
+		"+Use `code` here."
+	base := writeTempFile(t, "# Title\n## New *section*\n\n- See [the docs](http://example.com) <br>\n```go\nfmt.Println(\"not counted\")\n```\nUse `code` here.\n")
+	defer os.RemoveAll(filepath.Dir(base))
+	diffs := indiff.Diffs{
+		indiff.NewModifiedBase(indiff.NewFile(base, "en").Modified(patchtest.MustParse(patch)), indiff.NewFile("de/first.md", "de")),
+	}
+
+	// When effort is estimated
+	efforts, err := indiff.EstimateEffort(diffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then only words from added lines without markup should be counted
+	// "New", "section", "See", "the", "docs", "Use", "here."
+	expected := []*indiff.Effort{{Lang: "de", Words: 7, Characters: 28}}
+	if !reflect.DeepEqual(expected, efforts) {
+		t.Errorf("Unexpected effort. Should be `%+v` but was `%+v`", expected, efforts)
+	}
+}
+
+func TestEstimateEffortInCodeBlock(t *testing.T) {
+
+	// Given base file where line was added inside existing code block and after it
+	patch := "@@ -3,3 +3,5 @@\n" +
+		" fmt.Println(\"first\")\n" +
+		"+fmt.Println(\"second\")\n" +
+		" ```\n" +
+		" \n" +
+		"+New sentence"
+	base := writeTempFile(t, "# Title\n```go\nfmt.Println(\"first\")\nfmt.Println(\"second\")\n```\n\nNew sentence\n")
+	defer os.RemoveAll(filepath.Dir(base))
+	diffs := indiff.Diffs{
+		indiff.NewModifiedBase(indiff.NewFile(base, "en").Modified(patchtest.MustParse(patch)), indiff.NewFile("de/first.md", "de")),
+	}
+
+	// When effort is estimated
+	efforts, err := indiff.EstimateEffort(diffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then line added to code block should not be counted as code block is recognized in whole base file
+	expected := []*indiff.Effort{{Lang: "de", Words: 2, Characters: 11}}
+	if !reflect.DeepEqual(expected, efforts) {
+		t.Errorf("Unexpected effort. Should be `%+v` but was `%+v`", expected, efforts)
+	}
+}
+
+func TestEstimateEffortOfPossiblyStale(t *testing.T) {
+
+	// Given possibly stale translation, e.g. found by lockfile, without known changes of base file
+	base := writeTempFile(t, "# Title\n\nFirst sentence\n")
+	defer os.RemoveAll(filepath.Dir(base))
+	diffs := indiff.Diffs{
+		indiff.NewPossiblyStale(indiff.NewFile(base, "en"), indiff.NewFile("de/first.md", "de")),
+	}
+
+	// When effort is estimated
+	efforts, err := indiff.EstimateEffort(diffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then whole base file should be counted
+	expected := []*indiff.Effort{{Lang: "de", Words: 3, Characters: 18}}
+	if !reflect.DeepEqual(expected, efforts) {
+		t.Errorf("Unexpected effort. Should be `%+v` but was `%+v`", expected, efforts)
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+
+	tests := map[string][]string{
+		"yaml": {"---\ntitle: First\n---\n# First\n", "---\ntitle: First\n---\n"},
+		"toml": {"+++\ntitle = \"First\"\n+++ \n# First\n", "+++\ntitle = \"First\"\n+++ \n"},
+		"crlf": {"---\r\ntitle: First\r\n---\r\n# First\r\n", "---\r\ntitle: First\r\n---\r\n"},
+		"none": {"# First\n\n---\ntitle: First\n---\n", ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// When content is split to front matter and body
+			frontMatter, body := indiff.SplitFrontMatter(test[0])
+
+			// Then front matter should be found only at start of content
+			if frontMatter != test[1] || frontMatter+body != test[0] {
+				t.Errorf("Unexpected front matter. Should be `%q` but was `%q`", test[1], frontMatter)
+			}
+		})
+	}
+}
+
+// helpers
+
+// writeTempFile writes given content to file in new temporary directory and returns path to it
+func writeTempFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "first.md")
+	if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
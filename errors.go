@@ -0,0 +1,52 @@
+package indiff
+
+import "fmt"
+
+// PatternError says that pattern for matching translation files is malformed
+type PatternError struct {
+	Pattern string
+	Err     error
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("Invalid pattern '%s': %v", e.Pattern, e.Err)
+}
+
+// Unwrap returns underlying error
+func (e *PatternError) Unwrap() error {
+	return e.Err
+}
+
+// IOError says that file or directory on Path could not be read
+type IOError struct {
+	Path string
+	Err  error
+}
+
+func (e *IOError) Error() string {
+	return fmt.Sprintf("Unable to read %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns underlying error
+func (e *IOError) Unwrap() error {
+	return e.Err
+}
+
+// GitError says that git repository could not be read. Path is empty when failure is not related to single file.
+type GitError struct {
+	Revision string
+	Path     string
+	Err      error
+}
+
+func (e *GitError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("Unable to read revision %s: %v", e.Revision, e.Err)
+	}
+	return fmt.Sprintf("Unable to read %s in revision %s: %v", e.Path, e.Revision, e.Err)
+}
+
+// Unwrap returns underlying error
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
@@ -1,9 +1,15 @@
 package filesystem
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
 
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
 	"github.com/unravela/indiff"
 )
 
@@ -11,31 +17,157 @@ import (
 type Fs struct {
 	root    string
 	pattern Pattern
+	keyGlob glob.Glob
 }
 
 // NewFs creates new instance of Fs under specified root directory.
 // It accpets also GLOB like pattern which is used to distinguish which path belong to which language.
-func NewFs(root string, pattern Pattern) *Fs {
+// It returns PatternError when pattern is not valid.
+func NewFs(root string, pattern Pattern) (*Fs, error) {
+	keyGlob, err := pattern.Compile(indiff.KeyPlaceholder)
+	if err != nil {
+		return nil, err
+	}
 	return &Fs{
 		root:    root,
 		pattern: pattern,
+		keyGlob: keyGlob,
+	}, nil
+}
+
+// CollectFiles collects Files from file system for specified langs.
+// Root directory is walked only once, subdirectories are walked concurrently.
+// Files are sorted by language (in order of given langs) and path.
+// Symbolic links are not followed, but broken link matching pattern is reported as error.
+// It returns PatternError when pattern is not valid and IOError when some directory or link can't be read.
+func (fs *Fs) CollectFiles(langs []string) (indiff.Files, error) {
+	root, err := filepath.Abs(fs.root)
+	if err != nil {
+		return nil, &indiff.IOError{Path: fs.root, Err: err}
+	}
+	w := &walker{
+		root:    root,
+		globs:   make([]glob.Glob, len(langs)),
+		langs:   langs,
+		workers: make(chan struct{}, runtime.NumCPU()),
+	}
+	for i, lang := range langs {
+		if w.globs[i], err = fs.pattern.Compile(lang); err != nil {
+			return nil, err
+		}
 	}
+
+	w.wg.Add(1)
+	w.walk(root)
+	w.wg.Wait()
+
+	if len(w.errs) > 0 {
+		sort.Slice(w.errs, func(i, j int) bool { return w.errs[i].path < w.errs[j].path })
+		first := &indiff.IOError{Path: w.errs[0].path, Err: w.errs[0].err}
+		return nil, errors.Wrapf(first, "Unable to collect files (%d error(s))", len(w.errs))
+	}
+
+	sort.Slice(w.found, func(i, j int) bool {
+		if w.found[i].lang != w.found[j].lang {
+			return w.found[i].lang < w.found[j].lang
+		}
+		return w.found[i].file.Path < w.found[j].file.Path
+	})
+	files := make(indiff.Files, 0, len(w.found))
+	for _, f := range w.found {
+		files = append(files, f.file)
+	}
+	return files, nil
 }
 
-// CollectFiles collects Files from file system for specified langs
-func (fs *Fs) CollectFiles(langs []string) indiff.Files {
-	files := []*indiff.File{}
-	for _, lang := range langs {
-		glob := fs.pattern.Compile(lang)
+// walker walks directory tree concurrently and matches each file against globs of all languages
+type walker struct {
+	root    string
+	globs   []glob.Glob
+	langs   []string
+	workers chan struct{}
+	wg      sync.WaitGroup
 
-		filepath.Walk(fs.root, func(path string, info os.FileInfo, err error) error {
-			rel, _ := filepath.Rel(fs.root, path)
-			abs, _ := filepath.Abs(path)
-			if glob.Match(rel) {
-				files = append(files, indiff.NewFile(abs, lang))
+	mu    sync.Mutex
+	found []foundFile
+	errs  []walkError
+}
+
+// foundFile is file matching glob of language on index lang
+type foundFile struct {
+	lang int
+	file *indiff.File
+}
+
+// walkError is error which occurred on path
+type walkError struct {
+	path string
+	err  error
+}
+
+// walk processes given directory, its subdirectories are processed by free workers or sequentially when all workers are busy
+func (w *walker) walk(dir string) {
+	defer w.wg.Done()
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		w.fail(dir, err)
+		return
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if e.IsDir() {
+			w.wg.Add(1)
+			select {
+			case w.workers <- struct{}{}:
+				go func() {
+					defer func() { <-w.workers }()
+					w.walk(path)
+				}()
+			default:
+				w.walk(path)
+			}
+			continue
+		}
+		w.match(path, e)
+	}
+}
+
+// match adds file on given path to languages which globs match it
+func (w *walker) match(path string, info os.FileInfo) {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		w.fail(path, err)
+		return
+	}
+	for i, g := range w.globs {
+		if !g.Match(rel) {
+			continue
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if _, err := os.Stat(path); err != nil {
+				w.fail(path, err)
+				return
 			}
-			return nil
-		})
+		}
+		w.mu.Lock()
+		w.found = append(w.found, foundFile{lang: i, file: indiff.NewFile(path, w.langs[i])})
+		w.mu.Unlock()
+	}
+}
+
+// fail records error which occurred on given path
+func (w *walker) fail(path string, err error) {
+	w.mu.Lock()
+	w.errs = append(w.errs, walkError{path: path, err: err})
+	w.mu.Unlock()
+}
+
+// Keys is indiff.KeyFunc which creates key of file by replacing language code on position matched by pattern.
+// File which is not matched by pattern has no keys, so it's not paired with any other file.
+func (fs *Fs) Keys(f *indiff.File) []string {
+	rel, err := filepath.Rel(fs.root, f.Path)
+	if err != nil {
+		return []string{}
 	}
-	return files
+	return indiff.LangKeys(rel, f.Lang, fs.keyGlob.Match)
 }
@@ -2,11 +2,14 @@ package filesystem
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/unravela/indiff"
 )
 
@@ -17,10 +20,13 @@ func TestCollectFiles(t *testing.T) {
 		root := rootFolder("sub")
 
 		// Given files collector with predefined pattern for "subdirectory layout" and markdown files
-		fs := NewFs(root, MustParsePattern("SUB", []string{"md"}))
+		fs := newFs(t, root, MustParsePattern("SUB", []string{"md"}))
 
 		// When files are collected for languages "en" and "de"
-		files := fs.CollectFiles([]string{"en", "de"})
+		files, err := fs.CollectFiles([]string{"en", "de"})
+		if err != nil {
+			t.Fatal(err)
+		}
 
 		// Then expected files should be found in collection
 		expected := indiff.Files{
@@ -39,10 +45,13 @@ func TestCollectFiles(t *testing.T) {
 		root := rootFolder("ext")
 
 		// Given files collector with predefined pattern for "subdirectory layout" and markdown files
-		fs := NewFs(root, MustParsePattern("EXT", []string{"md"}))
+		fs := newFs(t, root, MustParsePattern("EXT", []string{"md"}))
 
 		// When files are collected for languages "en" and "de"
-		files := fs.CollectFiles([]string{"en", "de"})
+		files, err := fs.CollectFiles([]string{"en", "de"})
+		if err != nil {
+			t.Fatal(err)
+		}
 
 		// Then expected files should be found in collection
 		expected := indiff.Files{
@@ -58,8 +67,216 @@ func TestCollectFiles(t *testing.T) {
 
 }
 
+func TestCollectFilesInDeepTree(t *testing.T) {
+	// Given deep tree with many directories
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	expected := indiff.Files{}
+	for _, lang := range []string{"en", "de"} {
+		for i := 0; i < 20; i++ {
+			dir := filepath.Join(root, lang, fmt.Sprintf("d%02d", i), "a", "b")
+			path := filepath.Join(dir, "page.md")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(path, []byte("x"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			expected = append(expected, indiff.NewFile(path, lang))
+		}
+	}
+
+	// When files are collected for languages "en" and "de" (in this order)
+	files, err := newFs(t, root, MustParsePattern("SUB", []string{"md"})).CollectFiles([]string{"en", "de"})
+
+	// Then all files should be found sorted by language and path
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCollected(t, expected, files)
+}
+
+func TestCollectFilesWithBrokenLink(t *testing.T) {
+	// Given tree with broken symbolic link matching pattern
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	if err := os.MkdirAll(filepath.Join(root, "en"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "nowhere.md"), filepath.Join(root, "en", "broken.md")); err != nil {
+		t.Skip("symbolic links are not supported")
+	}
+
+	// When files are collected
+	_, err = newFs(t, root, MustParsePattern("SUB", []string{"md"})).CollectFiles([]string{"en", "de"})
+
+	// Then error should be returned
+	if err == nil || !strings.Contains(err.Error(), "broken.md") {
+		t.Errorf("Unexpected error. Should contain path to broken link but was `%v`", err)
+	}
+}
+
+func TestCollectFilesFromMissingRoot(t *testing.T) {
+	_, err := newFs(t, filepath.Join("..", "testdata", "missing"), MustParsePattern("SUB", nil)).CollectFiles([]string{"en"})
+	var ioErr *indiff.IOError
+	if !errors.As(err, &ioErr) {
+		t.Errorf("Unexpected error. Should be IOError but was `%v`", err)
+	}
+}
+
+func TestCollectFilesWithInvalidPattern(t *testing.T) {
+	// Given pattern with unclosed character class, which was not validated by ParsePattern
+	pattern := Pattern("%l/[a.md")
+
+	// When files collector is created
+	_, err := NewFs(rootFolder("sub"), pattern)
+
+	// Then PatternError should be returned
+	var patternErr *indiff.PatternError
+	if !errors.As(err, &patternErr) || patternErr.Pattern != string(pattern) {
+		t.Errorf("Unexpected error. Should be PatternError but was `%v`", err)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+
+	tests := []struct {
+		pattern    string
+		extensions []string
+		valid      bool
+	}{
+		{"SUB", []string{"md"}, true},
+		{"docs/%l/**.%e", nil, true},
+		{"docs/**.md", nil, false},
+		{"%l/[a-**.%e", nil, false},
+		{"%l/**.%e", []string{"md", "[rst"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			// When pattern is parsed
+			_, err := ParsePattern(test.pattern, test.extensions)
+
+			// Then invalid pattern should be reported as PatternError
+			var patternErr *indiff.PatternError
+			if test.valid && err != nil {
+				t.Errorf("Unexpected error of valid pattern: %v", err)
+			}
+			if !test.valid && !errors.As(err, &patternErr) {
+				t.Errorf("Unexpected error. Should be PatternError but was `%v`", err)
+			}
+		})
+	}
+}
+
+func TestTranslationPath(t *testing.T) {
+
+	tests := []struct {
+		pattern  string
+		basepath string
+		expected string
+	}{
+		{"SUB", filepath.Join("en", "section", "one.md"), filepath.Join("de", "section", "one.md")},
+		{"EXT", filepath.Join("en", "green.en.md"), filepath.Join("en", "green.de.md")},
+		{"doc/%l/**.%e", filepath.Join("doc", "en", "len.md"), filepath.Join("doc", "de", "len.md")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			// Given pattern for markdown files
+			pattern := MustParsePattern(test.pattern, []string{"md"})
+
+			// When path of translation to "de" is derived from "en"
+			path, ok, err := pattern.TranslationPath(test.basepath, "en", "de")
+
+			// Then derived path should match pattern for "de"
+			if !ok || err != nil || path != test.expected {
+				t.Errorf("Unexpected translation path. Should be `%s` but was `%s`", test.expected, path)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+
+	tests := []struct {
+		pattern     string
+		base        string
+		translation string
+	}{
+		{"SUB", filepath.Join("en", "section", "en.md"), filepath.Join("de", "section", "en.md")},
+		{"EXT", filepath.Join("en", "green.en.md"), filepath.Join("en", "green.de.md")},
+		{"doc/%l/**.%e", filepath.Join("doc", "en", "len.md"), filepath.Join("doc", "de", "len.md")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			// Given files collector with pattern for markdown files
+			fs := newFs(t, "/root", MustParsePattern(test.pattern, []string{"md"}))
+
+			// When keys of base file and its translation are created
+			baseKeys := fs.Keys(indiff.NewFile(filepath.Join("/root", test.base), "en"))
+			translationKeys := fs.Keys(indiff.NewFile(filepath.Join("/root", test.translation), "de"))
+
+			// Then there should be only one key derived from language code matched by pattern
+			if len(baseKeys) != 1 || !reflect.DeepEqual(baseKeys, translationKeys) {
+				t.Errorf("Unexpected keys. Should be same single key but was `%q` and `%q`", baseKeys, translationKeys)
+			}
+		})
+	}
+}
+
+func TestKeysNotMatchingPattern(t *testing.T) {
+
+	// Given files collector with pattern for markdown files in language subdirectories
+	fs := newFs(t, "/home/de-team", MustParsePattern("SUB", []string{"md"}))
+
+	// When keys of file outside of language subdirectory are created
+	keys := fs.Keys(indiff.NewFile(filepath.Join("/home/de-team", "doc", "de.md"), "de"))
+
+	// Then there should be no keys, so file is not paired with any other file
+	if len(keys) != 0 {
+		t.Errorf("Unexpected keys. Should be empty but was `%q`", keys)
+	}
+}
+
+func BenchmarkNewBundleWithKeys(b *testing.B) {
+	for _, count := range []int{100, 1000, 10000} {
+		files := indiff.Files{}
+		for l := 0; l < 20; l++ {
+			lang := fmt.Sprintf("l%02d", l)
+			for i := 0; i < count; i++ {
+				files = append(files, indiff.NewFile(fmt.Sprintf("/doc/%s/section-%d/page-%d.md", lang, i%50, i), lang))
+			}
+		}
+		fs, err := NewFs("/doc", MustParsePattern("SUB", []string{"md"}))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("fs-keys-20x%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				indiff.NewBundleWithKeys("l00", files, fs.Keys)
+			}
+		})
+	}
+}
+
 // helpers
 
+func newFs(t *testing.T, root string, pattern Pattern) *Fs {
+	fs, err := NewFs(root, pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
 func rootFolder(dir string) string {
 	root := filepath.Join("..", "testdata", "layout", dir)
 	root, _ = filepath.Abs(root)
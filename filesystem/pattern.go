@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gobwas/glob"
+	"github.com/unravela/indiff"
 )
 
 // Pattern represent GLOB like pattern for matching paths with translation files
@@ -27,12 +28,12 @@ var PredefinedPatterns = map[string][]string{
 // E.g. ParsePattern("%l/**.%e", {"md","rst"}) will produce pattern "%l/**.{md,rst}"
 func ParsePattern(rawPattern string, extensions []string) (Pattern, error) {
 	// parse pattern
-	pattern := PredefinedPatterns[rawPattern][0]
-	if pattern == "" {
-		pattern = rawPattern
+	pattern := rawPattern
+	if predefined, ok := PredefinedPatterns[rawPattern]; ok {
+		pattern = predefined[0]
 	}
 	if !strings.Contains(pattern, "%l") {
-		return "", fmt.Errorf("Pattern must contain placeholder for language code '%%l'")
+		return "", &indiff.PatternError{Pattern: rawPattern, Err: fmt.Errorf("Pattern must contain placeholder for language code '%%l'")}
 	}
 
 	// parse extensions
@@ -43,12 +44,16 @@ func ParsePattern(rawPattern string, extensions []string) (Pattern, error) {
 
 	// apply extensions to pattern
 	pattern = strings.Replace(pattern, "%e", extpattern, 1)
-	
+
+	// verify that pattern is valid GLOB
+	if _, err := Pattern(pattern).Compile("lang"); err != nil {
+		return "", err
+	}
 	return Pattern(pattern), nil
 }
 
 // MustParsePattern can be used when you are sure that your pattern is valid (e.g. one of predefined patterns).
-// It does same parsing as ParsePattern but panics when there will be an error.
+// It does same parsing as ParsePattern but panics when there will be an error, so it should not be used with user input.
 func MustParsePattern(rawPattern string, extensions []string) Pattern {
 	pattern, err := ParsePattern(rawPattern, extensions)
 	if err != nil {
@@ -57,8 +62,43 @@ func MustParsePattern(rawPattern string, extensions []string) Pattern {
 	return pattern
 }
 
-// Compile turns pattern into matcher for given lang
-func (p Pattern) Compile(lang string) glob.Glob {
+// Compile turns pattern into matcher for given lang. It returns PatternError when pattern is not valid GLOB.
+func (p Pattern) Compile(lang string) (glob.Glob, error) {
 	rawglob := strings.Replace(string(p), "%l", lang, 1)
-	return glob.MustCompile(rawglob, os.PathSeparator)
-}
\ No newline at end of file
+	g, err := glob.Compile(rawglob, os.PathSeparator)
+	if err != nil {
+		return nil, &indiff.PatternError{Pattern: string(p), Err: err}
+	}
+	return g, nil
+}
+
+// TranslationPath derives path of translation in given lang from path of file in baselang.
+// Both paths are relative to root directory. It returns false when no path matching pattern could be derived
+// and PatternError when pattern is not valid.
+//
+// E.g. for pattern "**.%l.%e" TranslationPath("doc/first.en.md", "en", "de") returns "doc/first.de.md"
+func (p Pattern) TranslationPath(basepath string, baselang string, lang string) (string, bool, error) {
+	baseglob, err := p.Compile(baselang)
+	if err != nil {
+		return "", false, err
+	}
+	glob, err := p.Compile(lang)
+	if err != nil {
+		return "", false, err
+	}
+	if !baseglob.Match(basepath) {
+		return "", false, nil
+	}
+
+	// try to replace each occurence of base language code until path matches pattern for given lang
+	for i := 0; i <= len(basepath)-len(baselang); i++ {
+		if basepath[i:i+len(baselang)] != baselang {
+			continue
+		}
+		candidate := basepath[:i] + lang + basepath[i+len(baselang):]
+		if glob.Match(candidate) {
+			return candidate, true, nil
+		}
+	}
+	return "", false, nil
+}
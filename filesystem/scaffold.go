@@ -0,0 +1,156 @@
+package filesystem
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// StubStrategy defines content of newly created translation file
+type StubStrategy string
+
+// Supported stub strategies
+const (
+	// StubCopy copies base file and adds "needs translation" banner to markdown and HTML files
+	StubCopy StubStrategy = "copy"
+	// StubEmpty creates empty file
+	StubEmpty StubStrategy = "empty"
+	// StubFrontMatter copies only front matter of base file with draft flag set
+	StubFrontMatter StubStrategy = "front-matter"
+)
+
+// ErrTranslationExists is returned when translation file to be created already exists
+var ErrTranslationExists = errors.New("Translation file already exists")
+
+// StubStrategies lists all supported stub strategies
+var StubStrategies = []StubStrategy{StubCopy, StubEmpty, StubFrontMatter}
+
+// Scaffold creates missing translation files on paths defined by pattern
+type Scaffold struct {
+	// Root is directory where pattern is applied
+	Root string
+	// Pattern defines where translation files belong
+	Pattern Pattern
+	// BaseLang is language of base files
+	BaseLang string
+	// Strategy defines content of created files
+	Strategy StubStrategy
+	// DryRun disables writing of files, paths are only resolved
+	DryRun bool
+}
+
+// Create creates translation file for each Missing diff and returns paths to created files
+// and paths to already existing files which were skipped
+func (s *Scaffold) Create(diffs indiff.Diffs) (created []string, skipped []string, err error) {
+	created, skipped = []string{}, []string{}
+	for _, d := range diffs {
+		if _, ok := d.(*indiff.Missing); !ok {
+			continue
+		}
+		path, err := s.CreateFile(d.Base(), d.Lang())
+		if err == ErrTranslationExists {
+			skipped = append(skipped, path)
+			continue
+		} else if err != nil {
+			return created, skipped, err
+		}
+		created = append(created, path)
+	}
+	return created, skipped, nil
+}
+
+// CreateFile creates translation of given base file in given language and returns path to it.
+// Existing file is never overwritten, ErrTranslationExists is returned with its path instead.
+func (s *Scaffold) CreateFile(base *indiff.File, lang string) (string, error) {
+	rel, err := filepath.Rel(s.Root, base.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Base file is not in root directory: %s", base.Path)
+	}
+	target, ok, err := s.Pattern.TranslationPath(rel, s.BaseLang, lang)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("Unable to derive path of translation to %s for: %s", lang, rel)
+	}
+	path := filepath.Join(s.Root, target)
+
+	if _, err := os.Stat(path); err == nil {
+		return path, ErrTranslationExists
+	}
+	if s.DryRun {
+		return path, nil
+	}
+
+	baseContent, err := ioutil.ReadFile(base.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Unable to read base file: %s", base.Path)
+	}
+	content, err := s.stub(string(baseContent), filepath.ToSlash(rel))
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return "", errors.Wrapf(err, "Unable to create directory: %s", filepath.Dir(path))
+	}
+	if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+		return "", errors.Wrapf(err, "Unable to write file: %s", path)
+	}
+	return path, nil
+}
+
+// stub creates content of translation file from content of base file on given relative path
+func (s *Scaffold) stub(base string, rel string) (string, error) {
+	switch s.Strategy {
+	case StubEmpty:
+		return "", nil
+	case StubCopy:
+		if !hasComments(rel) {
+			return base, nil
+		}
+		frontMatter, body := indiff.SplitFrontMatter(base)
+		banner := fmt.Sprintf("<!-- NEEDS TRANSLATION: copy of %s -->\n\n", rel)
+		return frontMatter + banner + body, nil
+	case StubFrontMatter:
+		frontMatter, _ := indiff.SplitFrontMatter(base)
+		return withDraft(frontMatter), nil
+	default:
+		return "", fmt.Errorf("Unknown stub strategy: %s", s.Strategy)
+	}
+}
+
+// hasComments says if HTML comment can be safely added to file on given path, i.e. it is markdown or HTML file
+func hasComments(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".html", ".htm":
+		return true
+	default:
+		return false
+	}
+}
+
+var draftRegexp = regexp.MustCompile(`(?m)^draft\s*[:=].*$`)
+
+// withDraft sets draft flag in given front matter, new YAML front matter is created when given one is empty
+func withDraft(frontMatter string) string {
+	if frontMatter == "" {
+		return "---\ndraft: true\n---\n"
+	}
+	draft := "draft: true"
+	if strings.HasPrefix(frontMatter, "+++") {
+		draft = "draft = true"
+	}
+	if draftRegexp.MatchString(frontMatter) {
+		return draftRegexp.ReplaceAllString(frontMatter, draft)
+	}
+	// insert draft flag after opening delimiter
+	nl := strings.Index(frontMatter, "\n")
+	return frontMatter[:nl+1] + draft + "\n" + frontMatter[nl+1:]
+}
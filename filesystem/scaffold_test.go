@@ -0,0 +1,99 @@
+package filesystem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/unravela/indiff"
+)
+
+func TestStub(t *testing.T) {
+
+	base := "---\ntitle: First\ndraft: false\n---\n# First\n"
+	tests := []struct {
+		strategy StubStrategy
+		base     string
+		expected string
+	}{
+		{StubEmpty, base, ""},
+		{StubCopy, base, "---\ntitle: First\ndraft: false\n---\n<!-- NEEDS TRANSLATION: copy of en/first.md -->\n\n# First\n"},
+		{StubFrontMatter, base, "---\ntitle: First\ndraft: true\n---\n"},
+		{StubFrontMatter, "+++\ntitle = \"First\"\n+++\n# First\n", "+++\ndraft = true\ntitle = \"First\"\n+++\n"},
+		{StubFrontMatter, "# First\n", "---\ndraft: true\n---\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.strategy), func(t *testing.T) {
+			// Given scaffold with stub strategy
+			s := &Scaffold{Strategy: test.strategy}
+
+			// When stub is created from base content
+			stub, err := s.stub(test.base, "en/first.md")
+
+			// Then stub should have expected content
+			if err != nil || stub != test.expected {
+				t.Errorf("Unexpected stub. Should be `%q` but was `%q`", test.expected, stub)
+			}
+		})
+	}
+}
+
+func TestStubCopyWithoutComments(t *testing.T) {
+
+	// Given scaffold copying base files
+	s := &Scaffold{Strategy: StubCopy}
+
+	// When stub is created from base file which does not support HTML comments
+	stub, err := s.stub("title: First\n", "en/first.yaml")
+
+	// Then stub should be exact copy without banner
+	if expected := "title: First\n"; err != nil || stub != expected {
+		t.Errorf("Unexpected stub. Should be `%q` but was `%q`", expected, stub)
+	}
+}
+
+func TestCreateSkipsExisting(t *testing.T) {
+
+	for _, dryRun := range []bool{false, true} {
+		// Given two missing translations, one of them created meanwhile
+		root, err := ioutil.TempDir("", "indiff")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(root)
+		for _, p := range []string{"en/first.md", "en/second.md", "de/first.md"} {
+			path := filepath.Join(root, filepath.FromSlash(p))
+			if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(path, []byte("# "+p), os.FileMode(0644)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		diffs := indiff.Diffs{
+			indiff.NewMissing(indiff.NewFile(filepath.Join(root, "en", "first.md"), "en"), "de"),
+			indiff.NewMissing(indiff.NewFile(filepath.Join(root, "en", "second.md"), "en"), "de"),
+		}
+
+		// When files are scaffolded
+		s := &Scaffold{Root: root, Pattern: MustParsePattern("SUB", nil), BaseLang: "en", Strategy: StubEmpty, DryRun: dryRun}
+		created, skipped, err := s.Create(diffs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Then existing file should be skipped and reported and other one created
+		if expected := []string{filepath.Join(root, "de", "second.md")}; !reflect.DeepEqual(expected, created) {
+			t.Errorf("Unexpected created files. Should be `%v` but was `%v`", expected, created)
+		}
+		if expected := []string{filepath.Join(root, "de", "first.md")}; !reflect.DeepEqual(expected, skipped) {
+			t.Errorf("Unexpected skipped files. Should be `%v` but was `%v`", expected, skipped)
+		}
+		if content, err := ioutil.ReadFile(filepath.Join(root, "de", "first.md")); err != nil || string(content) != "# de/first.md" {
+			t.Errorf("Unexpected content of existing file: `%s`", content)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package filesystem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// Staleness represents diff tool which recognizes possibly stale translations without Git.
+// Translation is possibly stale when its base file was modified later than translation.
+//
+// Optionally it can use cache with content hashes to ignore changes of modification time without content change.
+// Cache is maintained automatically: pair is considered in sync when translation content changes
+// and possibly stale when only base content changes since the pair was in sync.
+type Staleness struct {
+	root      string
+	cachePath string
+	cache     map[string]*syncState
+}
+
+// syncState holds content hashes of base file and translation from the time they were in sync
+type syncState struct {
+	BaseHash        string `json:"baseHash"`
+	TranslationHash string `json:"translationHash"`
+}
+
+// NewStaleness creates staleness checker for files under given root.
+// Content hashes are cached in file on given cachePath, empty path disables hashes.
+func NewStaleness(root string, cachePath string) (*Staleness, error) {
+	s := &Staleness{root: root, cachePath: cachePath, cache: map[string]*syncState{}}
+	if cachePath == "" {
+		return s, nil
+	}
+	content, err := ioutil.ReadFile(cachePath)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read cache: %s", cachePath)
+	}
+	if err := json.Unmarshal(content, &s.cache); err != nil {
+		return nil, errors.Wrapf(err, "Invalid cache: %s", cachePath)
+	}
+	return s, nil
+}
+
+// Diff returns PossiblyStale for each translation which seems to be older than its base file.
+// It returns IOError when some file can't be read.
+func (s *Staleness) Diff(bundle *indiff.Bundle) (indiff.Diffs, error) {
+	diffs := indiff.Diffs{}
+	for _, basepath := range bundle.BasePaths() {
+		base := indiff.NewFile(basepath, bundle.BaseLang())
+		for _, translation := range bundle.FilesInOtherLangs(basepath) {
+			stale, err := s.isStale(base, translation)
+			if err != nil {
+				return nil, err
+			}
+			if stale {
+				diffs = append(diffs, indiff.NewPossiblyStale(base, translation))
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// SaveCache writes cache with content hashes, it does nothing when hashes are disabled
+func (s *Staleness) SaveCache() error {
+	if s.cachePath == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(s.cache, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Unable to serialize cache")
+	}
+	if err := ioutil.WriteFile(s.cachePath, append(content, '\n'), os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write cache: %s", s.cachePath)
+	}
+	return nil
+}
+
+// isStale checks if given translation is possibly stale and updates cache
+func (s *Staleness) isStale(base *indiff.File, translation *indiff.File) (bool, error) {
+	staleByTime, err := isModifiedLater(base.Path, translation.Path)
+	if err != nil || s.cachePath == "" {
+		return staleByTime, err
+	}
+
+	baseHash, err := hashOfFile(base.Path)
+	if err != nil {
+		return false, err
+	}
+	translationHash, err := hashOfFile(translation.Path)
+	if err != nil {
+		return false, err
+	}
+
+	key := s.relative(translation.Path)
+	state := s.cache[key]
+	switch {
+	case state == nil && staleByTime:
+		// unknown pair, rely on modification time until translation changes
+		return true, nil
+	case state == nil || state.TranslationHash != translationHash:
+		// translation was made or updated
+		s.cache[key] = &syncState{BaseHash: baseHash, TranslationHash: translationHash}
+		return false, nil
+	default:
+		return state.BaseHash != baseHash, nil
+	}
+}
+
+// relative returns slash separated path relative to root
+func (s *Staleness) relative(path string) string {
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil {
+		rel = path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// isModifiedLater checks if file on path a was modified later than file on path b
+func isModifiedLater(a string, b string) (bool, error) {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false, &indiff.IOError{Path: a, Err: err}
+	}
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false, &indiff.IOError{Path: b, Err: err}
+	}
+	return ai.ModTime().After(bi.ModTime()), nil
+}
+
+// hashOfFile returns hex encoded SHA-256 hash of file content on given path
+func hashOfFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", &indiff.IOError{Path: path, Err: err}
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,90 @@
+package filesystem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+func TestStaleness(t *testing.T) {
+
+	// Given base file modified after its translation
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	base := filepath.Join(root, "en", "first.md")
+	translation := filepath.Join(root, "de", "first.md")
+	writeFile(t, base, "# First", time.Now())
+	writeFile(t, translation, "# Erste", time.Now().Add(-time.Hour))
+	bundle := indiff.NewBundle("en", indiff.Files{indiff.NewFile(base, "en"), indiff.NewFile(translation, "de")})
+	cache := filepath.Join(root, "cache.json")
+
+	// When translation is checked
+	s, _ := NewStaleness(root, cache)
+	diffs, err := s.Diff(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SaveCache()
+
+	// Then translation should be possibly stale
+	if len(diffs) != 1 || diffs[0].Kind() != indiff.KindPossiblyStale {
+		t.Errorf("Translation should be possibly stale but differences were `%s`", diffs)
+	}
+
+	// When translation is updated and then base file is only touched
+	writeFile(t, translation, "# Erste neue", time.Now().Add(time.Minute))
+	s, _ = NewStaleness(root, cache)
+	s.Diff(bundle)
+	s.SaveCache()
+	writeFile(t, base, "# First", time.Now().Add(time.Hour))
+
+	// Then translation should not be stale as content of base file is same
+	s, _ = NewStaleness(root, cache)
+	if diffs, _ := s.Diff(bundle); len(diffs) != 0 {
+		t.Errorf("Translation should not be stale but differences were `%s`", diffs)
+	}
+}
+
+func TestStalenessWithMissingFile(t *testing.T) {
+
+	// Given bundle with translation which does not exist on disk
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	base := filepath.Join(root, "en", "first.md")
+	translation := filepath.Join(root, "de", "first.md")
+	writeFile(t, base, "# First", time.Now())
+	bundle := indiff.NewBundle("en", indiff.Files{indiff.NewFile(base, "en"), indiff.NewFile(translation, "de")})
+
+	// When translation is checked
+	s, _ := NewStaleness(root, "")
+	_, err = s.Diff(bundle)
+
+	// Then IOError with path of missing translation should be returned
+	var ioErr *indiff.IOError
+	if !errors.As(err, &ioErr) || ioErr.Path != translation {
+		t.Errorf("Unexpected error. Should be IOError for `%s` but was `%v`", translation, err)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string, mtime time.Time) {
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
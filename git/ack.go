@@ -0,0 +1,153 @@
+package git
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// DefaultAcksPath is name of file with acknowledgments used when no other path is specified
+const DefaultAcksPath = ".indiff-ack.json"
+
+// Acks holds acknowledgments that translation is up-to-date with specific content of base file.
+// Content is identified by Git blob hash, so acknowledgment becomes invalid with next change of base file.
+type Acks struct {
+	root    string
+	entries map[ackKey]string
+}
+
+// ackKey identifies translation by path to base file and language
+type ackKey struct {
+	path string
+	lang string
+}
+
+// ack is serialized form of one acknowledgment
+type ack struct {
+	// Path to base file relative to root directory
+	Path string `json:"path"`
+	Lang string `json:"lang"`
+	// Hash is Git blob hash of acknowledged base file content
+	Hash string `json:"hash"`
+}
+
+// acksFile is serialized form of Acks
+type acksFile struct {
+	Acks []ack `json:"acks"`
+}
+
+// ReadAcks loads acknowledgments from file on given path. Paths in file are resolved against given root.
+// Empty Acks are returned when file does not exist.
+func ReadAcks(path string, root string) (*Acks, error) {
+	a := &Acks{root: root, entries: map[ackKey]string{}}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return a, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read acknowledgments file: %s", path)
+	}
+	var f acksFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, errors.Wrapf(err, "Invalid acknowledgments file: %s", path)
+	}
+	for _, e := range f.Acks {
+		a.entries[ackKey{path: e.Path, lang: e.Lang}] = e.Hash
+	}
+	return a, nil
+}
+
+// Write stores acknowledgments to file on given path
+func (a *Acks) Write(path string) error {
+	f := acksFile{Acks: []ack{}}
+	for k, hash := range a.entries {
+		f.Acks = append(f.Acks, ack{Path: k.path, Lang: k.lang, Hash: hash})
+	}
+	sort.Slice(f.Acks, func(i, j int) bool {
+		if f.Acks[i].Path != f.Acks[j].Path {
+			return f.Acks[i].Path < f.Acks[j].Path
+		}
+		return f.Acks[i].Lang < f.Acks[j].Lang
+	})
+	content, err := json.MarshalIndent(&f, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Unable to serialize acknowledgments")
+	}
+	if err := ioutil.WriteFile(path, append(content, '\n'), os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write acknowledgments file: %s", path)
+	}
+	return nil
+}
+
+// Add acknowledges that translation of base file on given path to given lang is up-to-date with current content of base file
+func (a *Acks) Add(basepath string, lang string) error {
+	content, err := ioutil.ReadFile(basepath)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read base file: %s", basepath)
+	}
+	key, err := a.keyOf(basepath, lang)
+	if err != nil {
+		return err
+	}
+	a.entries[key] = blobHash(string(content))
+	return nil
+}
+
+// Filter removes ModifiedBase and PossiblyStale differences which translations were acknowledged for current
+// content of base file. It is meant for differences found by other diff tools than Git. It returns IOError
+// when base file can't be read.
+func (a *Acks) Filter(diffs indiff.Diffs) (indiff.Diffs, error) {
+	filtered := indiff.Diffs{}
+	for _, d := range diffs {
+		if d.Kind() == indiff.KindModifiedBase || d.Kind() == indiff.KindPossiblyStale {
+			content, err := ioutil.ReadFile(d.Base().Path)
+			if err != nil {
+				return nil, &indiff.IOError{Path: d.Base().Path, Err: err}
+			}
+			if a.isAcked(d.Base().Path, d.Lang(), string(content)) {
+				continue
+			}
+		}
+		filtered = append(filtered, d)
+	}
+	return filtered, nil
+}
+
+// has checks if there is acknowledgment of translation of base file on given path to given lang for any content
+func (a *Acks) has(basepath string, lang string) bool {
+	key, err := a.keyOf(basepath, lang)
+	if err != nil {
+		return false
+	}
+	_, ok := a.entries[key]
+	return ok
+}
+
+// isAcked checks if translation of base file on given path to given lang was acknowledged for given content of base file
+func (a *Acks) isAcked(basepath string, lang string, content string) bool {
+	key, err := a.keyOf(basepath, lang)
+	if err != nil {
+		return false
+	}
+	hash, ok := a.entries[key]
+	return ok && hash == blobHash(content)
+}
+
+// keyOf creates key for given absolute path to base file and lang
+func (a *Acks) keyOf(basepath string, lang string) (ackKey, error) {
+	rel, err := filepath.Rel(a.root, basepath)
+	if err != nil {
+		return ackKey{}, errors.Wrapf(err, "Base file is not in root directory: %s", basepath)
+	}
+	return ackKey{path: filepath.ToSlash(rel), lang: lang}, nil
+}
+
+// blobHash returns Git blob hash of given content
+func blobHash(content string) string {
+	return plumbing.ComputeHash(plumbing.BlobObject, []byte(content)).String()
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAcksRoundTrip(t *testing.T) {
+
+	// Given base file in root directory
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	basepath := filepath.Join(root, "en", "first.md")
+	if err := os.MkdirAll(filepath.Dir(basepath), os.FileMode(0755)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(basepath, []byte("# First"), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, DefaultAcksPath)
+
+	// When translation is acknowledged, written and read again
+	acks, err := ReadAcks(path, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := acks.Add(basepath, "de"); err != nil {
+		t.Fatal(err)
+	}
+	if err := acks.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	acks, err = ReadAcks(path, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then translation should be acknowledged only for acknowledged content and language
+	if !acks.isAcked(basepath, "de", "# First") {
+		t.Errorf("Unexpected result. Translation to de should be acknowledged")
+	}
+	if acks.isAcked(basepath, "de", "# First changed") {
+		t.Errorf("Unexpected result. Translation to de should not be acknowledged after change of base file")
+	}
+	if acks.isAcked(basepath, "sk", "# First") {
+		t.Errorf("Unexpected result. Translation to sk should not be acknowledged")
+	}
+
+	// Then file should store path relative to root
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\n  \"acks\": [\n    {\n      \"path\": \"en/first.md\",\n      \"lang\": \"de\",\n      \"hash\": \"" + blobHash("# First") + "\"\n    }\n  ]\n}\n"
+	if string(content) != expected {
+		t.Errorf("Unexpected content of acknowledgments file. Should be `%s` but was `%s`", expected, content)
+	}
+}
@@ -56,15 +56,11 @@ func (c *revisionChange) fromPath() string {
 }
 
 // fromContent reads content of the file before change
-func (c *revisionChange) fromContent() string {
+func (c *revisionChange) fromContent() (string, error) {
 	if c.fromPath() == "" {
-		return ""
+		return "", nil
 	}
-	content, err := c.revisionRange.older.contentOf(c.fromPath())
-	if err != nil {
-		panic(err)
-	}
-	return content
+	return c.revisionRange.older.contentOf(c.fromPath())
 }
 
 // toPath returns path to file after change
@@ -73,15 +69,11 @@ func (c *revisionChange) toPath() string {
 }
 
 // toContent returns content of the file after change
-func (c *revisionChange) toContent() string {
+func (c *revisionChange) toContent() (string, error) {
 	if c.toPath() == "" {
-		return ""
-	}
-	content, err := c.revisionRange.newer.contentOf(c.toPath())
-	if err != nil {
-		panic(err)
+		return "", nil
 	}
-	return content
+	return c.revisionRange.newer.contentOf(c.toPath())
 }
 
 // code below was copied from go-git as it was private but needed for merkletrie.DiffTree
@@ -2,6 +2,7 @@ package git
 
 import (
 	"path/filepath"
+	"sort"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/pkg/errors"
@@ -11,11 +12,16 @@ import (
 // Git represents diff tool based on changes in Git repository.
 // Changes are bounded by specified revision range.
 // It should be used only as addition to Base diff tool as it does not recognize Missing translations.
+//
+// Changes of base files which do not require retranslation (e.g. typo fixes) can be suppressed
+// by one of SkipTags in commit message or by adding HTML comment `<!-- skip-translation -->` to the base file.
 type Git struct {
 	path          string
 	revisionRange *revisionRange
 	repo          *git.Repository
 	changes       revisionChanges
+	suppressed    map[string]bool
+	acks          *Acks
 }
 
 // ErrRepoNotFound indicates that there was no Git repository on given path
@@ -50,7 +56,18 @@ func OpenGit(path string, rangeRef *Range) (*Git, error) {
 		return nil, errors.Wrap(err, "Unable to collect changes in given range")
 	}
 
-	return &Git{path: rootPath, revisionRange: revisionRange, repo: repo, changes: changes}, nil
+	// collect changes marked as not requiring retranslation
+	suppressed, err := collectSuppressed(repo, revisionRange)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to collect suppressed changes in given range")
+	}
+
+	return &Git{path: rootPath, revisionRange: revisionRange, repo: repo, changes: changes, suppressed: suppressed}, nil
+}
+
+// UseAcks enables skipping of modified base files which were acknowledged as not requiring translation update
+func (g *Git) UseAcks(acks *Acks) {
+	g.acks = acks
 }
 
 // Diff produces differences based on changes to basefile vs changes to it's translation in specific language.
@@ -72,7 +89,12 @@ func OpenGit(path string, rangeRef *Range) (*Git, error) {
 // 	modify		  -				ModifiedBase
 // 	delete		  -   			  -
 //
-func (g *Git) Diff(bundle *indiff.Bundle) indiff.Diffs {
+// ModifiedBase is not produced when base change was suppressed or acknowledged (see UseAcks).
+//
+// It fails with indiff.GitError or indiff.IOError when content of changed base file can't be read.
+// Patches are read lazily, so their failures are returned when they are requested.
+//
+func (g *Git) Diff(bundle *indiff.Bundle) (indiff.Diffs, error) {
 	// collect only modified changes
 	modified := map[string]*revisionChange{}
 	g.changes.forEachCreatedOrModified(func(change *revisionChange) {
@@ -80,28 +102,106 @@ func (g *Git) Diff(bundle *indiff.Bundle) indiff.Diffs {
 		modified[path] = change
 	})
 
-	// create diffs from modified changes on files in base language
+	// create diffs from modified changes on files in base language, paths are sorted to get stable order
+	paths := make([]string, 0, len(modified))
+	for path := range modified {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
 	diffs := []indiff.Diff{}
-	for path, baseChange := range modified {
+	for _, path := range paths {
+		baseChange := modified[path]
 		files := bundle.FilesInOtherLangs(path)
 		if len(files) > 0 {
 			base := modify(indiff.NewFile(path, bundle.BaseLang()), baseChange)
+			suppressed := g.suppression(baseChange)
 			for _, f := range files {
 				fileChange := modified[f.Path]
 				if fileChange != nil {
 					diffs = append(diffs, indiff.NewModifiedBoth(base, modify(f, fileChange)))
-				} else {
+					continue
+				}
+				skip, err := g.isSkipped(path, f.Lang, baseChange, suppressed)
+				if err != nil {
+					return nil, err
+				}
+				if !skip {
 					diffs = append(diffs, indiff.NewModifiedBase(base, f))
 				}
 			}
 		}
 	}
 
-	return diffs
+	return diffs, nil
 }
 
+// isSkipped checks if given change of base file on given path does not require update of translation to given lang
+// as it was suppressed (see suppression) or acknowledged
+func (g *Git) isSkipped(path string, lang string, c *revisionChange, suppressed func() (bool, error)) (bool, error) {
+	if suppressed, err := suppressed(); suppressed || err != nil {
+		return suppressed, err
+	}
+	return g.isAcked(path, lang, c)
+}
+
+// suppression returns function checking if given change was marked as not requiring retranslation.
+// Check reads contents of changed file, so it is done only once for all languages.
+func (g *Git) suppression(c *revisionChange) func() (bool, error) {
+	checked := false
+	var suppressed bool
+	var err error
+	return func() (bool, error) {
+		if !checked {
+			suppressed, err = g.isSuppressed(c)
+			checked = true
+		}
+		return suppressed, err
+	}
+}
+
+// isSuppressed checks if given change was marked as not requiring retranslation
+func (g *Git) isSuppressed(c *revisionChange) (bool, error) {
+	if g.suppressed[c.toPath()] {
+		return true, nil
+	}
+	return introducesSkipMarker(c)
+}
+
+// isAcked checks if translation of base file on given path to given lang was acknowledged for content after given change.
+// Content is read only when there is some acknowledgment of the translation.
+func (g *Git) isAcked(path string, lang string, c *revisionChange) (bool, error) {
+	if g.acks == nil || !g.acks.has(path, lang) {
+		return false, nil
+	}
+	content, err := c.toContent()
+	if err != nil {
+		return false, err
+	}
+	return g.acks.isAcked(path, lang, content), nil
+}
+
+// modify turns file to modification with patch which is calculated only when it is requested
 func modify(file *indiff.File, c *revisionChange) *indiff.Modification {
-	// TODO: patch is calculated even if it is not shown at the end, find some "lazy loading" solution
-	patch := createPatchFromSingleChange(c)
-	return file.Modified(patch.String())
+	return indiff.NewLazyModification(file, func() (*indiff.Patch, error) {
+		return createPatchFromSingleChange(c)
+	})
+}
+
+// Root returns path to top-level directory of repository
+func (g *Git) Root() string {
+	return g.path
+}
+
+// Revision returns hash of commit where revision range ends.
+// When range ends in working tree, hash of HEAD is returned.
+func (g *Git) Revision() (string, error) {
+	if c := g.revisionRange.newer.commit; c != nil {
+		return c.Hash.String(), nil
+	}
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", errors.Wrap(err, "Unable to resolve HEAD")
+	}
+	return head.Hash().String(), nil
 }
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/unravela/indiff"
+)
+
+func TestRoot(t *testing.T) {
+
+	// Given repository with documents in subdirectory
+	r := newTestRepo(t)
+	defer r.remove()
+	r.commit("Initial", map[string]string{"doc/en/first.md": "# First"})
+
+	// When repository is opened from subdirectory
+	g, err := OpenGit(r.path("doc"), Uncommited)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then root should be top-level directory of repository
+	if g.Root() != r.root {
+		t.Errorf("Unexpected root. Should be `%s` but was `%s`", r.root, g.Root())
+	}
+}
+
+func TestDiffReadsOnlyNeededContent(t *testing.T) {
+
+	// Given base file modified together with its translation and base file modified alone with two translations
+	r := newTestRepo(t)
+	defer r.remove()
+	older := r.commit("Initial", map[string]string{
+		"en/both.md": "# Both", "de/both.md": "# Beide",
+		"en/base.md": "# Base", "de/base.md": "# Basis", "sk/base.md": "# Základ",
+	})
+	r.commit("Update", map[string]string{"en/both.md": "# Both updated", "de/both.md": "# Beide aktualisiert", "en/base.md": "# Base updated"})
+	bundle := indiff.NewBundle("en", indiff.Files{
+		r.file("en/both.md", "en"), r.file("de/both.md", "de"),
+		r.file("en/base.md", "en"), r.file("de/base.md", "de"), r.file("sk/base.md", "sk"),
+	})
+
+	// Given Git diff tool with acknowledgments of other translations which counts reads of file contents
+	g, err := OpenGit(r.root, &Range{Older: older.String(), Newer: "HEAD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acks, err := ReadAcks(r.path(DefaultAcksPath), r.root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.UseAcks(acks)
+	reads := countReads(g.revisionRange.older, g.revisionRange.newer)
+
+	// When differences are calculated
+	diffs, err := g.Diff(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then only contents of base file modified alone should be read to look for skip marker, once for all languages
+	if len(diffs) != 3 {
+		t.Errorf("Unexpected count of differences. Should be `%d` but was `%d`", 3, len(diffs))
+	}
+	if *reads != 2 {
+		t.Errorf("Unexpected count of reads. Should be `%d` but was `%d`", 2, *reads)
+	}
+}
+
+// helpers
+
+// countReads counts reads of file contents in given revision trees
+func countReads(trees ...*revisionTree) *int {
+	reads := 0
+	for _, tree := range trees {
+		contentOf := tree.contentOf
+		tree.contentOf = func(path string) (string, error) {
+			reads++
+			return contentOf(path)
+		}
+	}
+	return &reads
+}
@@ -1,7 +1,6 @@
 package git
 
 import (
-	"fmt"
 	"io/ioutil"
 
 	"github.com/go-git/go-git/v5"
@@ -10,6 +9,7 @@ import (
 	"github.com/go-git/go-git/v5/utils/merkletrie/filesystem"
 	"github.com/go-git/go-git/v5/utils/merkletrie/noder"
 	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
 )
 
 // Range represents revision range used to look up for the changes.
@@ -37,6 +37,7 @@ type revisionRange struct {
 // revisionTree enables access to files at specific revision
 type revisionTree struct {
 	root      noder.Noder
+	commit    *object.Commit // nil for working tree
 	contentOf func(path string) (string, error)
 }
 
@@ -73,37 +74,42 @@ func resolveNewerTree(repo *git.Repository, r *Range) (*revisionTree, error) {
 	return commitTree(repo, r.Newer)
 }
 
-// commitTree returns revisionTree for given repo and revision string resolvable to commit hash
+// commitTree returns revisionTree for given repo and revision string resolvable to commit hash.
+// Failures are reported as indiff.GitError.
 func commitTree(repo *git.Repository, rev string) (*revisionTree, error) {
 	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
 	if err != nil {
-		return nil, err
+		return nil, &indiff.GitError{Revision: rev, Err: err}
 	}
 	commit, err := repo.CommitObject(*hash)
 	if err != nil {
-		return nil, err
+		return nil, &indiff.GitError{Revision: rev, Err: err}
 	}
 	tree, err := commit.Tree()
 	if err != nil {
-		return nil, err
+		return nil, &indiff.GitError{Revision: rev, Err: err}
 	}
 	return &revisionTree{
-		root: object.NewTreeRootNode(tree),
+		root:   object.NewTreeRootNode(tree),
+		commit: commit,
 		contentOf: func(path string) (string, error) {
 			f, err := commit.File(path)
-			if err != nil {
-				return "", fmt.Errorf("File not found in revision %s: %s", path, rev)
+			if err == object.ErrFileNotFound {
+				return "", &indiff.GitError{Revision: rev, Path: path, Err: ErrFileNotFound}
+			} else if err != nil {
+				return "", &indiff.GitError{Revision: rev, Path: path, Err: err}
 			}
 			c, err := f.Contents()
 			if err != nil {
-				return "", errors.Wrapf(err, "Unable to read content of file from revision %s: %s", path, rev)
+				return "", &indiff.GitError{Revision: rev, Path: path, Err: err}
 			}
 			return c, nil
 		},
 	}, nil
 }
 
-// workingTree returns revisionTree based on working tree for given repo
+// workingTree returns revisionTree based on working tree for given repo.
+// Failures of reading files are reported as indiff.IOError as they are not related to repository.
 func workingTree(repo *git.Repository) (*revisionTree, error) {
 	tree, err := repo.Worktree()
 	if err != nil {
@@ -117,12 +123,12 @@ func workingTree(repo *git.Repository) (*revisionTree, error) {
 		contentOf: func(path string) (string, error) {
 			f, err := tree.Filesystem.Open(path)
 			if err != nil {
-				return "", fmt.Errorf("File not found in working tree: %s", path)
+				return "", &indiff.IOError{Path: path, Err: err}
 			}
 			defer f.Close()
 			b, err := ioutil.ReadAll(f)
 			if err != nil {
-				return "", errors.Wrapf(err, "Unable to read content of file from working tree: %s", path)
+				return "", &indiff.IOError{Path: path, Err: err}
 			}
 			return string(b), nil
 		},
@@ -0,0 +1,255 @@
+package git
+
+import (
+	"container/heap"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/pkg/errors"
+)
+
+// SkipTags contains tags which can be used in commit message to say that changes made by commit do not require retranslation
+var SkipTags = []string{"[skip-translation]", "i18n: no-retranslate"}
+
+// skipMarkerRegexp matches HTML comment which can be added to base file to say that change does not require retranslation.
+// E.g. `<!-- skip-translation -->` or `<!-- i18n: no-retranslate -->`
+var skipMarkerRegexp = regexp.MustCompile(`<!--\s*(skip-translation|i18n:\s*no-retranslate)\s*-->`)
+
+// isSkipMessage checks if given commit message contains one of SkipTags
+func isSkipMessage(message string) bool {
+	message = strings.ToLower(message)
+	for _, tag := range SkipTags {
+		if strings.Contains(message, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// introducesSkipMarker checks if skip marker was added to the file by given change
+func introducesSkipMarker(c *revisionChange) (bool, error) {
+	from, err := c.fromContent()
+	if err != nil {
+		return false, err
+	}
+	to, err := c.toContent()
+	if err != nil {
+		return false, err
+	}
+	return countSkipMarkers(to) > countSkipMarkers(from), nil
+}
+
+func countSkipMarkers(content string) int {
+	return len(skipMarkerRegexp.FindAllStringIndex(content, -1))
+}
+
+// collectSuppressed returns paths (relative to repository root) of files which were in given revisionRange
+// changed only by commits with one of SkipTags in message.
+// Uncommited changes in working tree can't be suppressed this way as they don't have any message.
+func collectSuppressed(repo *git.Repository, revisionRange *revisionRange) (map[string]bool, error) {
+	newer := revisionRange.newer.commit
+	if newer == nil {
+		head, err := commitTree(repo, "HEAD")
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to resolve HEAD")
+		}
+		newer = head.commit
+	}
+
+	// skipOnly holds for each touched path whether it was changed only by skip commits
+	skipOnly := map[string]bool{}
+	touch := func(path string, skip bool) {
+		if previous, ok := skipOnly[path]; ok {
+			skip = skip && previous
+		}
+		skipOnly[path] = skip
+	}
+
+	// walk commits reachable from newer but not from older revision and collect paths they touched
+	commits, err := rangeCommits(revisionRange.older.commit, newer)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to walk commits in given range")
+	}
+	for _, c := range commits {
+		paths, err := changedPaths(c)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to walk commits in given range")
+		}
+		skip := isSkipMessage(c.Message)
+		for _, p := range paths {
+			touch(p, skip)
+		}
+	}
+
+	// changes in working tree are never suppressed
+	if revisionRange.newer.commit == nil {
+		head := object.NewTreeRootNode(nil)
+		if tree, err := newer.Tree(); err == nil {
+			head = object.NewTreeRootNode(tree)
+		}
+		// fresh working tree is needed as its nodes can't be walked repeatedly
+		worktree, err := workingTree(repo)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to open working tree")
+		}
+		changes, err := merkletrie.DiffTree(head, worktree.root, diffTreeIsEquals)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to resolve uncommited changes")
+		}
+		for _, c := range changes {
+			touch(c.From.String(), false)
+			touch(c.To.String(), false)
+		}
+	}
+
+	suppressed := map[string]bool{}
+	for p, skip := range skipOnly {
+		if skip && p != "" {
+			suppressed[p] = true
+		}
+	}
+	return suppressed, nil
+}
+
+// commit flags used by rangeCommits
+const (
+	reachableFromNewer = 1 << iota
+	reachableFromOlder
+)
+
+// rangeCommits returns commits reachable from newer but not from older commit (like `git rev-list older..newer`).
+// Both histories are walked together from the most recent commits and the walk stops as soon as all commits
+// left to walk are reachable from older commit, so history shared by both commits is not walked.
+// Like in Git, commits with wrong (skewed) commit times may be misplaced.
+func rangeCommits(older *object.Commit, newer *object.Commit) ([]*object.Commit, error) {
+	if older.Hash == newer.Hash {
+		return nil, nil
+	}
+	flags := map[plumbing.Hash]int{older.Hash: reachableFromOlder, newer.Hash: reachableFromNewer}
+	queue := &commitQueue{older, newer}
+	heap.Init(queue)
+	walked := []*object.Commit{}
+	for queue.Len() > 0 {
+		// when all commits left are reachable from older commit, only already walked commits are marked
+		shared := !queue.interesting(flags)
+		c := heap.Pop(queue).(*object.Commit)
+		flag := flags[c.Hash]
+		if flag&reachableFromOlder == 0 {
+			walked = append(walked, c)
+		}
+		err := c.Parents().ForEach(func(parent *object.Commit) error {
+			previous, seen := flags[parent.Hash]
+			if shared && !seen {
+				return nil
+			}
+			flags[parent.Hash] = previous | flag
+			// parent is walked again when it becomes reachable from older commit after it was walked
+			if !seen || (flag&reachableFromOlder != 0 && previous&reachableFromOlder == 0) {
+				heap.Push(queue, parent)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits := []*object.Commit{}
+	for _, c := range walked {
+		if flags[c.Hash]&reachableFromOlder == 0 {
+			commits = append(commits, c)
+		}
+	}
+	return commits, nil
+}
+
+// commitQueue is heap of commits ordered from the most recent one
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int            { return len(q) }
+func (q commitQueue) Less(i, j int) bool  { return q[i].Committer.When.After(q[j].Committer.When) }
+func (q commitQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(c interface{}) { *q = append(*q, c.(*object.Commit)) }
+func (q *commitQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// interesting checks if some commit in queue is not reachable from older commit, so walk has to continue
+func (q commitQueue) interesting(flags map[plumbing.Hash]int) bool {
+	for _, c := range q {
+		if flags[c.Hash]&reachableFromOlder == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// changedPaths returns paths of files changed by given commit. Merge commit changes only paths which differ
+// from all its parents, other paths were changed by merged commits.
+func changedPaths(c *object.Commit) ([]string, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if c.NumParents() == 0 {
+		return diffPaths(nil, tree)
+	}
+	var paths []string
+	err = c.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return err
+		}
+		changed, err := diffPaths(parentTree, tree)
+		if err != nil {
+			return err
+		}
+		if paths == nil {
+			paths = changed
+		} else {
+			paths = intersect(paths, changed)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// diffPaths returns paths of files which differ in given trees
+func diffPaths(from *object.Tree, to *object.Tree) ([]string, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	for _, ch := range changes {
+		if ch.From.Name != "" {
+			paths = append(paths, ch.From.Name)
+		}
+		if ch.To.Name != "" && ch.To.Name != ch.From.Name {
+			paths = append(paths, ch.To.Name)
+		}
+	}
+	return paths, nil
+}
+
+// intersect returns paths contained in both given slices
+func intersect(a []string, b []string) []string {
+	inB := map[string]bool{}
+	for _, p := range b {
+		inB[p] = true
+	}
+	result := []string{}
+	for _, p := range a {
+		if inB[p] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
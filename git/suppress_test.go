@@ -0,0 +1,280 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/unravela/indiff"
+)
+
+func TestIsSkipMessage(t *testing.T) {
+	tests := map[string]bool{
+		"Fix typo [skip-translation]":           true,
+		"Fix typo\n\ni18n: No-Retranslate":      true,
+		"Rewrite introduction":                  false,
+		"Mention skip-translation in changelog": false,
+	}
+	for message, expected := range tests {
+		if skip := isSkipMessage(message); skip != expected {
+			t.Errorf("Unexpected result for message `%s`. Should be `%t` but was `%t`", message, expected, skip)
+		}
+	}
+}
+
+func TestCountSkipMarkers(t *testing.T) {
+	content := "# Title\n<!-- skip-translation -->\ntext <!--i18n:  no-retranslate--> text\n<!-- translation -->"
+	if count := countSkipMarkers(content); count != 2 {
+		t.Errorf("Unexpected count of skip markers. Should be `%d` but was `%d`", 2, count)
+	}
+}
+
+func TestDiffSuppressed(t *testing.T) {
+
+	// Given repository with base files and their translations
+	r := newTestRepo(t)
+	defer r.remove()
+	older := r.commit("Initial", map[string]string{
+		"en/message.md": "# Message", "de/message.md": "# Nachricht",
+		"en/marker.md": "# Marker", "de/marker.md": "# Markierung",
+		"en/changed.md": "# Changed", "de/changed.md": "# Geändert",
+	})
+
+	// Given base files changed by skip commit, by adding skip marker and by ordinary commit
+	r.commit("Fix typo [skip-translation]", map[string]string{"en/message.md": "# Messages"})
+	r.commit("Fix another typo", map[string]string{"en/marker.md": "# Markers\n<!-- skip-translation -->"})
+	r.commit("Rewrite", map[string]string{"en/changed.md": "# Changed completely"})
+	bundle := indiff.NewBundle("en", indiff.Files{
+		r.file("en/message.md", "en"), r.file("de/message.md", "de"),
+		r.file("en/marker.md", "en"), r.file("de/marker.md", "de"),
+		r.file("en/changed.md", "en"), r.file("de/changed.md", "de"),
+	})
+
+	// When differences are calculated
+	g, err := OpenGit(r.root, &Range{Older: older.String(), Newer: "HEAD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs, err := g.Diff(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then only ordinary change should be reported
+	if len(diffs) != 1 || diffs[0].Base().Path != r.path("en/changed.md") {
+		t.Errorf("Unexpected differences. Should be only modification of `%s` but was `%s`", r.path("en/changed.md"), diffs)
+	}
+}
+
+func TestCollectSuppressedFromUnrelatedRevision(t *testing.T) {
+
+	// Given history where older revision is on side branch, so it is not ancestor of newer revision
+	r := newTestRepo(t)
+	defer r.remove()
+	initial := r.commit("Initial", map[string]string{"en/first.md": "# First", "en/second.md": "# Second"})
+	r.commit("Fix typo [skip-translation]", map[string]string{"en/first.md": "# Firsts"})
+	r.commit("Rewrite", map[string]string{"en/second.md": "# Second rewritten"})
+	head := r.head()
+	r.checkout(initial, "side")
+	side := r.commit("Side change", map[string]string{"en/third.md": "# Third"})
+
+	// When suppressed changes between side branch and head are collected
+	older, err := commitTree(r.repo, side.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer, err := commitTree(r.repo, head.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	suppressed, err := collectSuppressed(r.repo, &revisionRange{older: older, newer: newer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then initial commit shared by both revisions should not be walked
+	expected := map[string]bool{"en/first.md": true}
+	if !reflect.DeepEqual(expected, suppressed) {
+		t.Errorf("Unexpected suppressed paths. Should be `%v` but was `%v`", expected, suppressed)
+	}
+}
+
+func TestCollectSuppressedThroughMerge(t *testing.T) {
+
+	// Given skip commit on side branch merged by merge commit which first parent is main branch
+	r := newTestRepo(t)
+	defer r.remove()
+	initial := r.commit("Initial", map[string]string{"en/first.md": "# First", "en/second.md": "# Second"})
+	main := r.commit("Rewrite", map[string]string{"en/second.md": "# Second rewritten"})
+	r.checkout(initial, "side")
+	side := r.commit("Fix typo [skip-translation]", map[string]string{"en/first.md": "# Firsts"})
+	merge := r.merge("Merge branch side", []plumbing.Hash{main, side}, map[string]string{"en/second.md": "# Second rewritten"})
+
+	// When suppressed changes between initial commit and merge are collected
+	older, err := commitTree(r.repo, initial.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer, err := commitTree(r.repo, merge.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	suppressed, err := collectSuppressed(r.repo, &revisionRange{older: older, newer: newer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then change brought by merge should stay suppressed
+	expected := map[string]bool{"en/first.md": true}
+	if !reflect.DeepEqual(expected, suppressed) {
+		t.Errorf("Unexpected suppressed paths. Should be `%v` but was `%v`", expected, suppressed)
+	}
+}
+
+func TestRangeCommits(t *testing.T) {
+
+	// Given history with side branch merged back to main branch
+	r := newTestRepo(t)
+	defer r.remove()
+	initial := r.commit("Initial", map[string]string{"en/first.md": "# First"})
+	first := r.commit("First", map[string]string{"en/first.md": "# First changed"})
+	r.checkout(initial, "side")
+	side := r.commit("Side", map[string]string{"en/side.md": "# Side"})
+	merge := r.merge("Merge", []plumbing.Hash{first, side}, map[string]string{"en/first.md": "# First changed"})
+	last := r.commit("Last", map[string]string{"en/last.md": "# Last"})
+
+	tests := []struct {
+		older    plumbing.Hash
+		newer    plumbing.Hash
+		expected []plumbing.Hash
+	}{
+		{last, last, []plumbing.Hash{}},
+		{initial, first, []plumbing.Hash{first}},
+		{first, last, []plumbing.Hash{last, merge, side}},
+		{side, last, []plumbing.Hash{last, merge, first}},
+		{merge, side, []plumbing.Hash{}},
+		{initial, last, []plumbing.Hash{last, merge, first, side}},
+	}
+	for _, test := range tests {
+		// When commits in range are walked
+		older, err := r.repo.CommitObject(test.older)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newer, err := r.repo.CommitObject(test.newer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commits, err := rangeCommits(older, newer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Then only commits reachable from newer but not from older commit should be returned
+		hashes := map[plumbing.Hash]bool{}
+		for _, c := range commits {
+			hashes[c.Hash] = true
+		}
+		expected := map[plumbing.Hash]bool{}
+		for _, h := range test.expected {
+			expected[h] = true
+		}
+		if len(commits) != len(expected) || !reflect.DeepEqual(expected, hashes) {
+			t.Errorf("Unexpected commits in range %s..%s. Should be `%v` but was `%v`", test.older, test.newer, test.expected, commits)
+		}
+	}
+}
+
+// helpers
+
+// testRepo is Git repository in temporary directory
+type testRepo struct {
+	t    *testing.T
+	root string
+	repo *git.Repository
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testRepo{t: t, root: root, repo: repo}
+}
+
+// commit writes given files (path to content) and commits them with given message
+func (r *testRepo) commit(message string, files map[string]string) plumbing.Hash {
+	return r.merge(message, nil, files)
+}
+
+// merge writes given files (path to content) and commits them with given message and parents,
+// current commit is the only parent when no parents are given
+func (r *testRepo) merge(message string, parents []plumbing.Hash, files map[string]string) plumbing.Hash {
+	w, err := r.repo.Worktree()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(r.path(path)), os.FileMode(0755)); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(r.path(path), []byte(content), os.FileMode(0644)); err != nil {
+			r.t.Fatal(err)
+		}
+		if _, err := w.Add(path); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	hash, err := w.Commit(message, &git.CommitOptions{
+		Author:  &object.Signature{Name: "indiff", Email: "indiff@example.com", When: time.Now()},
+		Parents: parents,
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return hash
+}
+
+// checkout creates new branch with given name on given commit and switches to it
+func (r *testRepo) checkout(hash plumbing.Hash, branch string) {
+	w, err := r.repo.Worktree()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	err = w.Checkout(&git.CheckoutOptions{Hash: hash, Branch: plumbing.NewBranchReferenceName(branch), Create: true})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+// head returns hash of current commit
+func (r *testRepo) head() plumbing.Hash {
+	ref, err := r.repo.Head()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return ref.Hash()
+}
+
+// path returns absolute path of file on given slash separated path relative to repository root
+func (r *testRepo) path(rel string) string {
+	return filepath.Join(r.root, filepath.FromSlash(rel))
+}
+
+func (r *testRepo) file(rel string, lang string) *indiff.File {
+	return indiff.NewFile(r.path(rel), lang)
+}
+
+func (r *testRepo) remove() {
+	os.RemoveAll(r.root)
+}
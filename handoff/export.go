@@ -0,0 +1,223 @@
+package handoff
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/render"
+)
+
+// Export creates translation packages with everything translators need to resolve differences.
+// One package (directory or zip archive) is created for each language.
+//
+// Package contains base files for Missing translations, base files, base patches
+// and current translations for ModifiedBase differences and base files with current translations
+// for PossiblyStale differences, all described by Manifest.
+type Export struct {
+	// Root is directory to which paths in package are relative
+	Root string
+	// BaseLang is language of base files
+	BaseLang string
+	// Revision is Git commit from which differences were calculated
+	Revision string
+	// Zip enables creating of zip archives instead of directories
+	Zip bool
+}
+
+// Write creates packages for given diffs in given output directory and returns paths to them
+func (e *Export) Write(out string, diffs indiff.Diffs) ([]string, error) {
+	byLang := map[string]indiff.Diffs{}
+	for _, d := range diffs {
+		switch d.(type) {
+		case *indiff.Missing, *indiff.ModifiedBase, *indiff.PossiblyStale:
+			byLang[d.Lang()] = append(byLang[d.Lang()], d)
+		}
+	}
+	langs := make([]string, 0, len(byLang))
+	for lang := range byLang {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	if err := os.MkdirAll(out, os.FileMode(0755)); err != nil {
+		return nil, errors.Wrapf(err, "Unable to create output directory: %s", out)
+	}
+
+	packages := []string{}
+	for _, lang := range langs {
+		p, err := e.writePackage(out, lang, byLang[lang])
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to create package for language %s", lang)
+		}
+		packages = append(packages, p)
+	}
+	return packages, nil
+}
+
+// writePackage creates package for single language
+func (e *Export) writePackage(out string, lang string, diffs indiff.Diffs) (string, error) {
+	var w packageWriter
+	var err error
+	pkgPath := filepath.Join(out, lang)
+	if e.Zip {
+		pkgPath += ".zip"
+		w, err = newZipWriter(pkgPath)
+	} else {
+		w, err = newDirWriter(pkgPath)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+
+	manifest := &Manifest{
+		Lang:     lang,
+		BaseLang: e.BaseLang,
+		Revision: e.Revision,
+		Created:  time.Now().UTC(),
+		Entries:  []*Entry{},
+	}
+	for _, d := range diffs {
+		entry, err := e.writeEntry(w, d)
+		if err != nil {
+			return "", err
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "Unable to serialize manifest")
+	}
+	if err := w.Write(ManifestName, append(content, '\n')); err != nil {
+		return "", err
+	}
+	return pkgPath, w.Close()
+}
+
+// writeEntry writes files needed to resolve given diff to package and describes them with Entry
+func (e *Export) writeEntry(w packageWriter, d indiff.Diff) (*Entry, error) {
+	entry := &Entry{Kind: d.Kind(), Base: e.relative(d.Base())}
+
+	base, err := ioutil.ReadFile(d.Base().Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read base file: %s", d.Base().Path)
+	}
+	entry.BaseHash = HashOf(base)
+	if err := w.Write(path.Join(BaseDir, entry.Base), base); err != nil {
+		return nil, err
+	}
+
+	if t := d.Translation(); t != nil {
+		entry.Translation = e.relative(t)
+		translation, err := ioutil.ReadFile(t.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to read translation file: %s", t.Path)
+		}
+		entry.TranslationHash = HashOf(translation)
+		if err := w.Write(path.Join(TranslationDir, entry.Translation), translation); err != nil {
+			return nil, err
+		}
+	}
+
+	// changes of possibly stale base file are unknown, so only modified base has patch
+	if mb, ok := d.(*indiff.ModifiedBase); ok {
+		patch, err := mb.BasePatch()
+		if err != nil {
+			return nil, err
+		}
+		entry.Patch = path.Join(PatchDir, entry.Base+".diff")
+		if err := w.Write(entry.Patch, []byte(render.Unified(patch)+"\n")); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// relative returns slash separated path of given file relative to root
+func (e *Export) relative(f *indiff.File) string {
+	rel, err := filepath.Rel(e.Root, f.Path)
+	if err != nil {
+		rel = f.Path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// packageWriter writes files into package
+type packageWriter interface {
+	// Write writes content to file on given slash separated path inside package
+	Write(name string, content []byte) error
+	// Close finishes package, it can be called repeatedly
+	Close() error
+}
+
+// dirWriter writes package as plain directory
+type dirWriter struct {
+	root string
+}
+
+func newDirWriter(root string) (*dirWriter, error) {
+	if err := os.MkdirAll(root, os.FileMode(0755)); err != nil {
+		return nil, errors.Wrapf(err, "Unable to create package directory: %s", root)
+	}
+	return &dirWriter{root: root}, nil
+}
+
+func (w *dirWriter) Write(name string, content []byte) error {
+	p := filepath.Join(w.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), os.FileMode(0755)); err != nil {
+		return errors.Wrapf(err, "Unable to create directory: %s", filepath.Dir(p))
+	}
+	if err := ioutil.WriteFile(p, content, os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write file: %s", p)
+	}
+	return nil
+}
+
+func (w *dirWriter) Close() error {
+	return nil
+}
+
+// zipWriter writes package as zip archive
+type zipWriter struct {
+	file   *os.File
+	writer *zip.Writer
+}
+
+func newZipWriter(path string) (*zipWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to create archive: %s", path)
+	}
+	return &zipWriter{file: f, writer: zip.NewWriter(f)}, nil
+}
+
+func (w *zipWriter) Write(name string, content []byte) error {
+	f, err := w.writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to add file to archive: %s", name)
+	}
+	_, err = f.Write(content)
+	return errors.Wrapf(err, "Unable to write file to archive: %s", name)
+}
+
+func (w *zipWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.writer.Close()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	w.file = nil
+	return errors.Wrap(err, "Unable to finish archive")
+}
@@ -0,0 +1,113 @@
+package handoff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/internal/patchtest"
+)
+
+func TestExport(t *testing.T) {
+
+	for _, zip := range []bool{false, true} {
+		t.Run(map[bool]string{false: "directory", true: "zip"}[zip], func(t *testing.T) {
+			// Given missing translation, modified base file, possibly stale translation and modified both files
+			root := tempDir(t)
+			defer os.RemoveAll(root)
+			writeFile(t, filepath.Join(root, "en", "first.md"), "# First")
+			writeFile(t, filepath.Join(root, "en", "second.md"), "# Second")
+			writeFile(t, filepath.Join(root, "de", "second.md"), "# Zweite")
+			writeFile(t, filepath.Join(root, "en", "third.md"), "# Third")
+			writeFile(t, filepath.Join(root, "de", "third.md"), "# Dritte")
+			patch := patchtest.MustParse("@@ -0,0 +1 @@\n+# Second")
+			diffs := indiff.Diffs{
+				indiff.NewMissing(indiff.NewFile(filepath.Join(root, "en", "first.md"), "en"), "de"),
+				indiff.NewModifiedBase(
+					indiff.NewFile(filepath.Join(root, "en", "second.md"), "en").Modified(patch),
+					indiff.NewFile(filepath.Join(root, "de", "second.md"), "de"),
+				),
+				indiff.NewPossiblyStale(
+					indiff.NewFile(filepath.Join(root, "en", "third.md"), "en"),
+					indiff.NewFile(filepath.Join(root, "de", "third.md"), "de"),
+				),
+				indiff.NewModifiedBoth(
+					indiff.NewFile(filepath.Join(root, "en", "third.md"), "en").Modified(patch),
+					indiff.NewFile(filepath.Join(root, "sk", "third.md"), "sk").Modified(patch),
+				),
+			}
+
+			// When diffs are exported
+			out := tempDir(t)
+			defer os.RemoveAll(out)
+			export := &Export{Root: root, BaseLang: "en", Revision: "abc", Zip: zip}
+			packages, err := export.Write(out, diffs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Then only one package should be created because modified both files are not exported
+			expectedPackages := []string{filepath.Join(out, "de")}
+			if zip {
+				expectedPackages[0] += ".zip"
+			}
+			if !reflect.DeepEqual(expectedPackages, packages) {
+				t.Fatalf("Unexpected packages. Should be `%v` but was `%v`", expectedPackages, packages)
+			}
+
+			// Then manifest should describe every exported difference
+			r, err := openPackage(packages[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			content, err := r.Read(ManifestName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			manifest := &Manifest{}
+			if err := json.Unmarshal(content, manifest); err != nil {
+				t.Fatal(err)
+			}
+			if manifest.Lang != "de" || manifest.BaseLang != "en" || manifest.Revision != "abc" {
+				t.Errorf("Unexpected manifest header: %+v", manifest)
+			}
+			expected := []*Entry{
+				{Kind: indiff.KindMissing, Base: "en/first.md", BaseHash: HashOf([]byte("# First"))},
+				{
+					Kind: indiff.KindModifiedBase, Base: "en/second.md", BaseHash: HashOf([]byte("# Second")),
+					Translation: "de/second.md", TranslationHash: HashOf([]byte("# Zweite")), Patch: "patch/en/second.md.diff",
+				},
+				{
+					Kind: indiff.KindPossiblyStale, Base: "en/third.md", BaseHash: HashOf([]byte("# Third")),
+					Translation: "de/third.md", TranslationHash: HashOf([]byte("# Dritte")),
+				},
+			}
+			if !reflect.DeepEqual(expected, manifest.Entries) {
+				t.Errorf("Unexpected entries. Should be `%+v` but was `%+v`", expected, manifest.Entries)
+			}
+
+			// Then package should contain base files, translations and patch of modified base
+			files := map[string]string{
+				"base/en/first.md":         "# First",
+				"base/en/second.md":        "# Second",
+				"base/en/third.md":         "# Third",
+				"translation/de/second.md": "# Zweite",
+				"translation/de/third.md":  "# Dritte",
+				"patch/en/second.md.diff":  "@@ -0,0 +1 @@\n+# Second\n",
+			}
+			for name, expected := range files {
+				content, err := r.Read(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(content) != expected {
+					t.Errorf("Unexpected content of %s. Should be `%q` but was `%q`", name, expected, content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package handoff
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/internal/patchtest"
+)
+
+func TestExportImport(t *testing.T) {
+
+	for _, zip := range []bool{false, true} {
+		t.Run(map[bool]string{false: "directory", true: "zip"}[zip], func(t *testing.T) {
+			// Given root with base file without translation, modified base file and possibly stale translation
+			root := tempDir(t)
+			defer os.RemoveAll(root)
+			writeFile(t, filepath.Join(root, "en", "first.md"), "# First")
+			writeFile(t, filepath.Join(root, "en", "second.md"), "# Second")
+			writeFile(t, filepath.Join(root, "de", "second.md"), "# Zweite")
+			writeFile(t, filepath.Join(root, "en", "third.md"), "# Third")
+			writeFile(t, filepath.Join(root, "de", "third.md"), "# Dritte")
+			patch, err := patchtest.Parse("@@ -0,0 +1 @@\n+# Second")
+			if err != nil {
+				t.Fatal(err)
+			}
+			diffs := indiff.Diffs{
+				indiff.NewMissing(indiff.NewFile(filepath.Join(root, "en", "first.md"), "en"), "de"),
+				indiff.NewModifiedBase(
+					indiff.NewFile(filepath.Join(root, "en", "second.md"), "en").Modified(patch),
+					indiff.NewFile(filepath.Join(root, "de", "second.md"), "de"),
+				),
+				indiff.NewPossiblyStale(
+					indiff.NewFile(filepath.Join(root, "en", "third.md"), "en"),
+					indiff.NewFile(filepath.Join(root, "de", "third.md"), "de"),
+				),
+			}
+
+			// When diffs are exported
+			out := tempDir(t)
+			defer os.RemoveAll(out)
+			export := &Export{Root: root, BaseLang: "en", Revision: "abc", Zip: zip}
+			packages, err := export.Write(out, diffs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// When translated files are added to package (only directory can be simply modified)
+			pkg := packages[0]
+			if zip {
+				pkg = filepath.Join(out, "unzipped")
+				unzip(t, packages[0], pkg)
+			}
+			writeFile(t, filepath.Join(pkg, TranslatedDir, "en", "first.md"), "# Erste")
+			writeFile(t, filepath.Join(pkg, TranslatedDir, "en", "second.md"), "# Zweite neue")
+			writeFile(t, filepath.Join(pkg, TranslatedDir, "en", "third.md"), "# Dritte neue")
+
+			// When translation of modified base is modified after export
+			writeFile(t, filepath.Join(root, "de", "second.md"), "# Zweite lokal")
+
+			// When package is imported
+			imp := &Import{Root: root, Pattern: filesystem.MustParsePattern("SUB", nil), Revision: "abc"}
+			report, err := imp.Read(pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Then only missing and unmodified possibly stale translations should be imported
+			if !reflect.DeepEqual(report.Imported, []string{"de/first.md", "de/third.md"}) {
+				t.Errorf("Unexpected imported files: %v", report.Imported)
+			}
+			if !reflect.DeepEqual(report.Conflicts, []string{"de/second.md"}) {
+				t.Errorf("Unexpected conflicts: %v", report.Conflicts)
+			}
+			assertContent(t, filepath.Join(root, "de", "first.md"), "# Erste")
+			assertContent(t, filepath.Join(root, "de", "second.md"), "# Zweite lokal")
+			assertContent(t, filepath.Join(root, "de", "third.md"), "# Dritte neue")
+		})
+	}
+}
+
+func TestImportOutsideRoot(t *testing.T) {
+
+	tests := map[string]*Entry{
+		"base":    {Base: "../outside.md"},
+		"unclean": {Base: "en/../../outside.md"},
+	}
+
+	for name, entry := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Given package with entry pointing outside of root
+			root := tempDir(t)
+			defer os.RemoveAll(root)
+			pkg := tempDir(t)
+			defer os.RemoveAll(pkg)
+			writeFile(t, filepath.Join(root, "en", "first.md"), "# First")
+			manifest, err := json.Marshal(&Manifest{Lang: "de", BaseLang: "en", Entries: []*Entry{entry}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(pkg, ManifestName), string(manifest))
+			writeFile(t, filepath.Join(pkg, TranslatedDir, filepath.FromSlash(entry.Base)), "# Erste")
+
+			// When package is imported
+			imp := &Import{Root: root, Pattern: filesystem.MustParsePattern("SUB", nil)}
+			_, err = imp.Read(pkg)
+
+			// Then import should fail without writing anything
+			if !errors.Is(err, ErrOutsideRoot) {
+				t.Errorf("Unexpected error. Should be `%v` but was `%v`", ErrOutsideRoot, err)
+			}
+			for _, p := range []string{filepath.Join(filepath.Dir(root), ".bashrc"), filepath.Join(filepath.Dir(root), "outside.md")} {
+				if _, err := os.Stat(p); err == nil {
+					os.Remove(p)
+					t.Errorf("Unexpected file written outside of root: %s", p)
+				}
+			}
+		})
+	}
+}
+
+func TestImportNotMatchingPattern(t *testing.T) {
+
+	tests := map[string]*Manifest{
+		"translation": {Lang: "de", BaseLang: "en", Entries: []*Entry{{Base: "en/first.md", Translation: ".git/hooks/pre-commit"}}},
+		"lockfile":    {Lang: "de", BaseLang: "en", Entries: []*Entry{{Base: "en/first.md", Translation: ".indiff.lock"}}},
+		"outside":     {Lang: "de", BaseLang: "en", Entries: []*Entry{{Base: "en/first.md", Translation: "../../.bashrc"}}},
+		"absolute":    {Lang: "de", BaseLang: "en", Entries: []*Entry{{Base: "en/first.md", Translation: "/tmp/indiff.md"}}},
+		"base":        {Lang: "de", BaseLang: "en", Entries: []*Entry{{Base: ".git/hooks/pre-commit"}}},
+		"lang":        {Lang: ".git", BaseLang: "en", Entries: []*Entry{{Base: "en/hooks/pre-commit"}}},
+		"baselang":    {Lang: "de", BaseLang: "../en", Entries: []*Entry{{Base: "en/first.md"}}},
+	}
+
+	for name, manifest := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Given hostile package with entry which paths or languages don't match pattern
+			root := tempDir(t)
+			defer os.RemoveAll(root)
+			pkg := tempDir(t)
+			defer os.RemoveAll(pkg)
+			writeFile(t, filepath.Join(root, "en", "first.md"), "# First")
+			writeFile(t, filepath.Join(root, "en", "hooks", "pre-commit"), "#!/bin/sh")
+			writeFile(t, filepath.Join(root, ".git", "hooks", "pre-commit"), "#!/bin/sh")
+			writeFile(t, filepath.Join(root, ".indiff.lock"), "{}")
+			content, err := json.Marshal(manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(pkg, ManifestName), string(content))
+			writeFile(t, filepath.Join(pkg, TranslatedDir, filepath.FromSlash(manifest.Entries[0].Base)), "rm -rf /")
+
+			// When package is imported
+			imp := &Import{Root: root, Pattern: filesystem.MustParsePattern("SUB", nil)}
+			_, err = imp.Read(pkg)
+
+			// Then import should fail without overwriting any file
+			if !errors.Is(err, ErrNotMatchingPattern) {
+				t.Errorf("Unexpected error. Should be `%v` but was `%v`", ErrNotMatchingPattern, err)
+			}
+			assertContent(t, filepath.Join(root, ".git", "hooks", "pre-commit"), "#!/bin/sh")
+			assertContent(t, filepath.Join(root, ".indiff.lock"), "{}")
+			if _, err := os.Stat(filepath.Join(filepath.Dir(root), ".bashrc")); err == nil {
+				t.Errorf("Unexpected file written outside of root")
+			}
+		})
+	}
+}
+
+func TestImportToBaseLang(t *testing.T) {
+	// Given package with translations to base language
+	root := tempDir(t)
+	defer os.RemoveAll(root)
+	pkg := tempDir(t)
+	defer os.RemoveAll(pkg)
+	writeFile(t, filepath.Join(root, "en", "first.md"), "# First")
+	writeFile(t, filepath.Join(pkg, ManifestName), `{"lang": "en", "baseLang": "en", "entries": [{"base": "en/first.md"}]}`)
+	writeFile(t, filepath.Join(pkg, TranslatedDir, "en", "first.md"), "# Overwritten")
+
+	// When package is imported
+	_, err := (&Import{Root: root, Pattern: filesystem.MustParsePattern("SUB", nil)}).Read(pkg)
+
+	// Then import should fail and base file should be kept
+	if err == nil {
+		t.Errorf("Unexpected success of import to base language")
+	}
+	assertContent(t, filepath.Join(root, "en", "first.md"), "# First")
+}
+
+// helpers
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertContent(t *testing.T, path string, expected string) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != expected {
+		t.Errorf("Unexpected content of %s. Should be `%s` but was `%s`", path, expected, content)
+	}
+}
+
+func unzip(t *testing.T, archive string, dir string) {
+	r, err := openPackage(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for _, f := range r.(*zipReader).reader.File {
+		content, err := r.Read(f.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(dir, filepath.FromSlash(f.Name)), string(content))
+	}
+}
@@ -0,0 +1,249 @@
+package handoff
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff/filesystem"
+)
+
+// TranslatedDir is directory inside package where translators put translated files under paths of their base files
+const TranslatedDir = "translated"
+
+// ErrModifiedSinceExport is returned when translation file was modified after package was exported
+var ErrModifiedSinceExport = errors.New("translation was modified since export")
+
+// ErrOutsideRoot is returned when path from package is absolute or points outside of root directory
+var ErrOutsideRoot = errors.New("path points outside of root directory")
+
+// ErrNotMatchingPattern is returned when path or language from package doesn't match pattern
+var ErrNotMatchingPattern = errors.New("path doesn't match pattern")
+
+// langSpecialChars can't be part of language code from manifest, they would change meaning of pattern
+const langSpecialChars = "/\\.*?[]{}!"
+
+// Import places translated files from translation package to paths defined by pattern
+type Import struct {
+	// Root is directory to which paths in package are relative
+	Root string
+	// Pattern is used to derive paths of new translation files
+	Pattern filesystem.Pattern
+	// Revision is current Git commit (empty when Git is not used)
+	Revision string
+	// Force enables overwriting of translations modified since export
+	Force bool
+}
+
+// ImportReport summarizes result of import
+type ImportReport struct {
+	// Manifest of imported package
+	Manifest *Manifest
+	// RevisionChanged says that current revision differs from revision in manifest
+	RevisionChanged bool
+	// Imported holds paths to written translation files
+	Imported []string
+	// Untranslated holds base paths of entries without translated file in package
+	Untranslated []string
+	// Conflicts holds paths to translation files which were modified since export and were not overwritten
+	Conflicts []string
+	// ChangedBases holds paths to base files which were modified since export
+	ChangedBases []string
+}
+
+// Read imports translated files from package (directory or zip archive) on given path
+func (i *Import) Read(pkg string) (*ImportReport, error) {
+	r, err := openPackage(pkg)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := r.Read(ManifestName)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to read manifest")
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errors.Wrap(err, "Invalid manifest")
+	}
+	if manifest.Lang == "" || manifest.Lang == manifest.BaseLang {
+		return nil, errors.Errorf("Invalid manifest: language of translations must differ from base language '%s'", manifest.BaseLang)
+	}
+	for _, lang := range []string{manifest.Lang, manifest.BaseLang} {
+		if lang == "" || strings.ContainsAny(lang, langSpecialChars) {
+			return nil, errors.Wrapf(ErrNotMatchingPattern, "Invalid manifest: invalid language code '%s'", lang)
+		}
+	}
+
+	report := &ImportReport{
+		Manifest:        manifest,
+		RevisionChanged: manifest.Revision != "" && i.Revision != "" && manifest.Revision != i.Revision,
+		Imported:        []string{},
+		Untranslated:    []string{},
+		Conflicts:       []string{},
+		ChangedBases:    []string{},
+	}
+	for _, entry := range manifest.Entries {
+		if err := i.importEntry(r, manifest, entry, report); err != nil {
+			return nil, errors.Wrapf(err, "Unable to import translation of: %s", entry.Base)
+		}
+	}
+	return report, nil
+}
+
+// importEntry places translated file for single entry and records result to report.
+// Target path is always derived from pattern, paths from manifest are only checked against it.
+func (i *Import) importEntry(r packageReader, manifest *Manifest, entry *Entry, report *ImportReport) error {
+	// verify base file
+	base, err := ResolvePath(i.Root, entry.Base)
+	if err != nil {
+		return err
+	}
+	target, ok, err := i.Pattern.TranslationPath(filepath.FromSlash(entry.Base), manifest.BaseLang, manifest.Lang)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Wrapf(ErrNotMatchingPattern, "Unable to derive path of translation to %s from pattern %s", manifest.Lang, i.Pattern)
+	}
+	target = filepath.ToSlash(target)
+	if entry.Translation != "" && entry.Translation != target {
+		return errors.Wrapf(ErrNotMatchingPattern, "Translation path %s differs from path %s derived from pattern %s", entry.Translation, target, i.Pattern)
+	}
+	if hash, err := hashOfFile(base); err != nil || hash != entry.BaseHash {
+		report.ChangedBases = append(report.ChangedBases, entry.Base)
+	}
+
+	// read translated file
+	translated, err := r.Read(path.Join(TranslatedDir, entry.Base))
+	if os.IsNotExist(errors.Cause(err)) {
+		report.Untranslated = append(report.Untranslated, entry.Base)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	abs, err := ResolvePath(i.Root, target)
+	if err != nil {
+		return err
+	}
+	if abs == base {
+		return errors.Errorf("Translation can't overwrite base file: %s", entry.Base)
+	}
+
+	// refuse to overwrite translation modified since export
+	if !i.Force {
+		if err := CheckUnmodified(abs, entry.TranslationHash); err == ErrModifiedSinceExport {
+			report.Conflicts = append(report.Conflicts, target)
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(abs), os.FileMode(0755)); err != nil {
+		return errors.Wrapf(err, "Unable to create directory: %s", filepath.Dir(abs))
+	}
+	if err := ioutil.WriteFile(abs, translated, os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write file: %s", abs)
+	}
+	report.Imported = append(report.Imported, target)
+	return nil
+}
+
+// CheckUnmodified returns ErrModifiedSinceExport when translation file on given path differs from its state
+// at the time of export described by given hash. Empty hash means that translation file did not exist.
+func CheckUnmodified(path string, hash string) error {
+	current, err := hashOfFile(path)
+	if os.IsNotExist(errors.Cause(err)) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if current != hash {
+		return ErrModifiedSinceExport
+	}
+	return nil
+}
+
+// ResolvePath converts slash separated path relative to given root to cleaned path inside root.
+// It returns ErrOutsideRoot for absolute path and for path leading out of root (e.g. `../x.md`).
+func ResolvePath(root string, rel string) (string, error) {
+	rel = filepath.FromSlash(rel)
+	if rel == "" || filepath.IsAbs(rel) || filepath.VolumeName(rel) != "" {
+		return "", errors.Wrapf(ErrOutsideRoot, "Invalid path: %s", rel)
+	}
+	abs := filepath.Join(root, rel)
+	inner, err := filepath.Rel(filepath.Clean(root), abs)
+	if err != nil || inner == "." || inner == ".." || strings.HasPrefix(inner, ".."+string(filepath.Separator)) {
+		return "", errors.Wrapf(ErrOutsideRoot, "Invalid path: %s", rel)
+	}
+	return abs, nil
+}
+
+// packageReader reads files from package
+type packageReader interface {
+	// Read returns content of file on given slash separated path inside package
+	Read(name string) ([]byte, error)
+	// Close releases package
+	Close() error
+}
+
+// openPackage opens package on given path as zip archive or directory
+func openPackage(pkg string) (packageReader, error) {
+	info, err := os.Stat(pkg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to open package: %s", pkg)
+	}
+	if info.IsDir() {
+		return &dirReader{root: pkg}, nil
+	}
+	z, err := zip.OpenReader(pkg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to open archive: %s", pkg)
+	}
+	return &zipReader{reader: z}, nil
+}
+
+// dirReader reads package from plain directory
+type dirReader struct {
+	root string
+}
+
+func (r *dirReader) Read(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(r.root, filepath.FromSlash(name)))
+}
+
+func (r *dirReader) Close() error {
+	return nil
+}
+
+// zipReader reads package from zip archive
+type zipReader struct {
+	reader *zip.ReadCloser
+}
+
+func (r *zipReader) Read(name string) ([]byte, error) {
+	for _, f := range r.reader.File {
+		if strings.TrimPrefix(f.Name, "./") != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+	return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+}
+
+func (r *zipReader) Close() error {
+	return r.reader.Close()
+}
@@ -0,0 +1,66 @@
+package handoff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// ManifestName is name of manifest file inside translation package
+const ManifestName = "manifest.json"
+
+// Directories inside translation package
+const (
+	BaseDir        = "base"
+	TranslationDir = "translation"
+	PatchDir       = "patch"
+)
+
+// Manifest describes content of translation package for one language
+type Manifest struct {
+	// Lang is language to which files should be translated
+	Lang string `json:"lang"`
+	// BaseLang is language of base files
+	BaseLang string `json:"baseLang"`
+	// Revision is Git commit from which package was created (empty when Git was not used)
+	Revision string `json:"revision,omitempty"`
+	// Created is time when package was created
+	Created time.Time `json:"created"`
+	// Entries hold one entry per difference
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry describes files needed to resolve one difference. All paths use `/` as separator.
+type Entry struct {
+	// Kind of difference
+	Kind indiff.Kind `json:"kind"`
+	// Base is path to base file relative to root directory
+	Base string `json:"base"`
+	// BaseHash is hash of base file content at the time of export
+	BaseHash string `json:"baseHash"`
+	// Translation is path to translation file relative to root directory (empty for missing translation)
+	Translation string `json:"translation,omitempty"`
+	// TranslationHash is hash of translation file content at the time of export
+	TranslationHash string `json:"translationHash,omitempty"`
+	// Patch is path to base patch inside package (only for modified base)
+	Patch string `json:"patch,omitempty"`
+}
+
+// HashOf returns hex encoded SHA-256 hash of given content, it identifies state of files at the time of export
+func HashOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashOfFile returns hex encoded SHA-256 hash of file content on given path
+func hashOfFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Unable to read file: %s", path)
+	}
+	return HashOf(content), nil
+}
@@ -0,0 +1,78 @@
+// Package patchtest creates patches from their text for tests
+package patchtest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// Parse creates patch from its text in unified format.
+// File headers before first hunk are ignored, ranges of hunks are calculated from their lines.
+func Parse(text string) (*indiff.Patch, error) {
+	patch := &indiff.Patch{Hunks: []*indiff.Hunk{}}
+	if strings.TrimSpace(text) == "" {
+		return patch, nil
+	}
+	var current *indiff.Hunk
+	oldNumber, newNumber := 0, 0
+	for i, raw := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if m := hunkHeaderRegexp.FindStringSubmatch(raw); m != nil {
+			oldStart, _ := strconv.Atoi(m[1])
+			newStart, _ := strconv.Atoi(m[2])
+			current = &indiff.Hunk{OldStart: oldStart, NewStart: newStart}
+			patch.Hunks = append(patch.Hunks, current)
+			oldNumber, newNumber = oldStart, newStart
+			if oldNumber == 0 {
+				oldNumber = 1
+			}
+			if newNumber == 0 {
+				newNumber = 1
+			}
+			continue
+		}
+		if current == nil || strings.HasPrefix(raw, "\\") {
+			// file header or "no newline at end of file" marker
+			continue
+		}
+
+		l := &indiff.Line{Op: indiff.LineEqual}
+		switch {
+		case strings.HasPrefix(raw, "+"):
+			l.Op, l.Content, l.NewNumber = indiff.LineAdded, raw[1:], newNumber
+			newNumber++
+			current.NewLines++
+		case strings.HasPrefix(raw, "-"):
+			l.Op, l.Content, l.OldNumber = indiff.LineDeleted, raw[1:], oldNumber
+			oldNumber++
+			current.OldLines++
+		case raw == "" || strings.HasPrefix(raw, " "):
+			l.Content, l.OldNumber, l.NewNumber = strings.TrimPrefix(raw, " "), oldNumber, newNumber
+			oldNumber++
+			newNumber++
+			current.OldLines++
+			current.NewLines++
+		default:
+			return nil, fmt.Errorf("Invalid patch: unexpected line %d: %s", i+1, raw)
+		}
+		current.Lines = append(current.Lines, l)
+	}
+	if len(patch.Hunks) == 0 {
+		return nil, fmt.Errorf("Invalid patch: no hunk found")
+	}
+	return patch, nil
+}
+
+// MustParse creates patch from its text in unified format, it panics on invalid text
+func MustParse(text string) *indiff.Patch {
+	patch, err := Parse(text)
+	if err != nil {
+		panic(err)
+	}
+	return patch
+}
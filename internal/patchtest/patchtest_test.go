@@ -0,0 +1,42 @@
+package patchtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/unravela/indiff"
+)
+
+func TestParse(t *testing.T) {
+
+	// Given patch text with file header, two hunks and missing newline marker
+	text := "--- a/en/a.md\n+++ b/en/a.md\n" +
+		"@@ -1,2 +1,2 @@\n a\n-b\n+c\n" +
+		"@@ -0,0 +10 @@\n+d\n\\ No newline at end of file"
+
+	// When patch is parsed
+	patch, err := Parse(text)
+
+	// Then lines should be numbered according to hunk headers
+	expected := &indiff.Patch{Hunks: []*indiff.Hunk{
+		{OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 2, Lines: []*indiff.Line{
+			{Op: indiff.LineEqual, Content: "a", OldNumber: 1, NewNumber: 1},
+			{Op: indiff.LineDeleted, Content: "b", OldNumber: 2},
+			{Op: indiff.LineAdded, Content: "c", NewNumber: 2},
+		}},
+		{OldStart: 0, OldLines: 0, NewStart: 10, NewLines: 1, Lines: []*indiff.Line{
+			{Op: indiff.LineAdded, Content: "d", NewNumber: 10},
+		}},
+	}}
+	if err != nil || !reflect.DeepEqual(patch, expected) {
+		t.Errorf("Unexpected patch. Should be `%+v` but was `%+v` (%v)", expected, patch, err)
+	}
+	if added := patch.Lines(indiff.LineAdded); len(added) != 2 || added[1].Content != "d" {
+		t.Errorf("Unexpected added lines `%+v`", added)
+	}
+
+	// Then invalid text should fail
+	if _, err := Parse("not a patch"); err == nil {
+		t.Errorf("Unexpected success of parsing invalid patch")
+	}
+}
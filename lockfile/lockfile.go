@@ -0,0 +1,144 @@
+package lockfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// DefaultPath is name of lockfile used when no other path is specified
+const DefaultPath = ".indiff.lock"
+
+// Lockfile represents diff tool based on recorded state of translations.
+// For each translation file it records hash of base file content from which it was translated,
+// so it does not need Git to recognize outdated translations.
+// It should be used only as addition to Base diff tool as it does not recognize Missing translations.
+type Lockfile struct {
+	root    string
+	entries map[string]*Entry
+}
+
+// Entry records state of one translation file. All paths are slash separated and relative to root directory.
+type Entry struct {
+	// Translation is path to translation file
+	Translation string `json:"translation"`
+	// Base is path to base file
+	Base string `json:"base"`
+	// BaseHash is SHA-256 hash of base file content from which translation was made
+	BaseHash string `json:"baseHash"`
+}
+
+// file is serialized form of Lockfile
+type file struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// Read loads lockfile from given path. Paths in lockfile are resolved against given root.
+// Empty lockfile is returned when file does not exist.
+func Read(path string, root string) (*Lockfile, error) {
+	l := &Lockfile{root: root, entries: map[string]*Entry{}}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read lockfile: %s", path)
+	}
+	var f file
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, errors.Wrapf(err, "Invalid lockfile: %s", path)
+	}
+	for _, e := range f.Entries {
+		l.entries[e.Translation] = e
+	}
+	return l, nil
+}
+
+// Write stores lockfile to given path
+func (l *Lockfile) Write(path string) error {
+	f := file{Entries: make([]*Entry, 0, len(l.entries))}
+	for _, e := range l.entries {
+		f.Entries = append(f.Entries, e)
+	}
+	sort.Slice(f.Entries, func(i, j int) bool { return f.Entries[i].Translation < f.Entries[j].Translation })
+	content, err := json.MarshalIndent(&f, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Unable to serialize lockfile")
+	}
+	if err := ioutil.WriteFile(path, append(content, '\n'), os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write lockfile: %s", path)
+	}
+	return nil
+}
+
+// Update records current content of base file for its translation in given lang.
+// When onlyNew is true, existing entry is not changed. It returns true when entry was changed.
+func (l *Lockfile) Update(bundle *indiff.Bundle, basepath string, lang string, onlyNew bool) (bool, error) {
+	translation := bundle.FileInLang(basepath, lang)
+	if translation == nil {
+		return false, nil
+	}
+	e, err := l.entryOf(indiff.NewFile(basepath, bundle.BaseLang()), translation)
+	if err != nil {
+		return false, err
+	}
+	current := l.entries[e.Translation]
+	if current != nil && (onlyNew || *current == *e) {
+		return false, nil
+	}
+	l.entries[e.Translation] = e
+	return true, nil
+}
+
+// Diff returns PossiblyStale for each translation which base file changed since it was recorded in lockfile.
+// Lockfile does not hold previous content of base file, so change can't be described by patch.
+// Translations not recorded in lockfile are ignored. It returns IOError when some base file can't be read.
+func (l *Lockfile) Diff(bundle *indiff.Bundle) (indiff.Diffs, error) {
+	diffs := indiff.Diffs{}
+	for _, basepath := range bundle.BasePaths() {
+		for _, translation := range bundle.FilesInOtherLangs(basepath) {
+			recorded := l.entries[l.relative(translation.Path)]
+			if recorded == nil {
+				continue
+			}
+			base := indiff.NewFile(basepath, bundle.BaseLang())
+			current, err := l.entryOf(base, translation)
+			if err != nil {
+				return nil, err
+			}
+			if current.BaseHash != recorded.BaseHash {
+				diffs = append(diffs, indiff.NewPossiblyStale(base, translation))
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// entryOf creates entry with current state of given base file and translation
+func (l *Lockfile) entryOf(base *indiff.File, translation *indiff.File) (*Entry, error) {
+	content, err := ioutil.ReadFile(base.Path)
+	if err != nil {
+		return nil, &indiff.IOError{Path: base.Path, Err: err}
+	}
+	sum := sha256.Sum256(content)
+	return &Entry{
+		Translation: l.relative(translation.Path),
+		Base:        l.relative(base.Path),
+		BaseHash:    hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// relative returns slash separated path relative to root
+func (l *Lockfile) relative(path string) string {
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil {
+		rel = path
+	}
+	return filepath.ToSlash(rel)
+}
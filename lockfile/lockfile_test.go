@@ -0,0 +1,68 @@
+package lockfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+func TestDiff(t *testing.T) {
+
+	// Given root with two base files and their translations
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	files := indiff.Files{}
+	for _, p := range []string{"en/first.md", "en/second.md", "de/first.md", "de/second.md"} {
+		path := filepath.Join(root, filepath.FromSlash(p))
+		writeFile(t, path, p)
+		files = append(files, indiff.NewFile(path, filepath.Dir(p)))
+	}
+	bundle := indiff.NewBundle("en", files)
+
+	// Given lockfile with all translations recorded
+	lock, _ := Read(filepath.Join(root, DefaultPath), root)
+	for _, p := range bundle.BasePaths() {
+		if _, err := lock.Update(bundle, p, "de", false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// When one base file is modified
+	writeFile(t, filepath.Join(root, "en", "first.md"), "modified")
+
+	// Then only its translation should be outdated
+	expected := indiff.Diffs{
+		indiff.NewPossiblyStale(files[0], files[2]),
+	}
+	if diffs, err := lock.Diff(bundle); err != nil || !reflect.DeepEqual(expected, diffs) {
+		t.Errorf("Unexpected differences. Should be `%s` but was `%s` (error: %v)", expected, diffs, err)
+	}
+
+	// When base file is removed
+	if err := os.Remove(files[0].Path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then IOError should be returned
+	var ioErr *indiff.IOError
+	if _, err := lock.Diff(bundle); !errors.As(err, &ioErr) || ioErr.Path != files[0].Path {
+		t.Errorf("Unexpected error. Should be IOError for `%s` but was `%v`", files[0].Path, err)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package indiff
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Order defines how differences are sorted
+type Order string
+
+// Orders of differences known to indiff, each of them uses remaining criteria (language, kind, path) as tie-breakers
+const (
+	// OrderLang sorts by language, then kind, then path. It is default order.
+	OrderLang Order = "lang"
+	// OrderKind sorts by kind, then language, then path
+	OrderKind Order = "kind"
+	// OrderPath sorts by path of base file, then language, then kind
+	OrderPath Order = "path"
+)
+
+// Orders lists all orders of differences
+var Orders = []Order{OrderLang, OrderKind, OrderPath}
+
+// ParseOrder converts given name to Order, empty name is default order
+func ParseOrder(name string) (Order, error) {
+	if name == "" {
+		return OrderLang, nil
+	}
+	for _, o := range Orders {
+		if string(o) == name {
+			return o, nil
+		}
+	}
+	return "", fmt.Errorf("unknown order '%s'", name)
+}
+
+// Sort returns copy of given differences sorted in given order, empty order is default order.
+// Result does not depend on order of given differences.
+func Sort(diffs Diffs, order Order) Diffs {
+	sorted := append(Diffs{}, diffs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareDiffs(sorted[i], sorted[j], order) < 0
+	})
+	return sorted
+}
+
+// compareDiffs compares given differences by criteria of given order
+func compareDiffs(a Diff, b Diff, order Order) int {
+	byLang := func() int { return compareStrings(a.Lang(), b.Lang()) }
+	byKind := func() int { return kindIndex(a.Kind()) - kindIndex(b.Kind()) }
+	byPath := func() int { return compareStrings(a.Base().Path, b.Base().Path) }
+	byTranslation := func() int { return compareStrings(translationPath(a), translationPath(b)) }
+
+	criteria := []func() int{byLang, byKind, byPath, byTranslation}
+	switch order {
+	case OrderKind:
+		criteria = []func() int{byKind, byLang, byPath, byTranslation}
+	case OrderPath:
+		criteria = []func() int{byPath, byLang, byKind, byTranslation}
+	}
+	for _, compare := range criteria {
+		if c := compare(); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// kindIndex returns position of given kind in Kinds, unknown kinds are last
+func kindIndex(k Kind) int {
+	for i, kind := range Kinds {
+		if kind == k {
+			return i
+		}
+	}
+	return len(Kinds)
+}
+
+// translationPath returns path of translation or empty string when it does not exist
+func translationPath(d Diff) string {
+	if t := d.Translation(); t != nil {
+		return t.Path
+	}
+	return ""
+}
+
+func compareStrings(a string, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
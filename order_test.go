@@ -0,0 +1,45 @@
+package indiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+
+	// Given differences of different kinds, languages and paths
+	missingDeB := NewMissing(NewFile("en/b.md", "en"), "de")
+	missingSkA := NewMissing(NewFile("en/a.md", "en"), "sk")
+	modifiedDeA := NewModifiedBase(NewFile("en/a.md", "en").Modified(nil), NewFile("de/a.md", "de"))
+	staleSkB := NewPossiblyStale(NewFile("en/b.md", "en"), NewFile("sk/b.md", "sk"))
+	diffs := Diffs{staleSkB, modifiedDeA, missingSkA, missingDeB}
+
+	tests := map[Order]Diffs{
+		"":        {missingDeB, modifiedDeA, missingSkA, staleSkB},
+		OrderLang: {missingDeB, modifiedDeA, missingSkA, staleSkB},
+		OrderKind: {missingDeB, missingSkA, modifiedDeA, staleSkB},
+		OrderPath: {modifiedDeA, missingSkA, missingDeB, staleSkB},
+	}
+	for order, expected := range tests {
+		// When diffs are sorted
+		sorted := Sort(diffs, order)
+
+		// Then order should be stable and independent on input order
+		if !reflect.DeepEqual(sorted, expected) {
+			t.Errorf("Unexpected order `%s`. Should be `%v` but was `%v`", order, expected, sorted)
+		}
+		reversed := Diffs{missingDeB, missingSkA, modifiedDeA, staleSkB}
+		if !reflect.DeepEqual(Sort(reversed, order), expected) {
+			t.Errorf("Unexpected order `%s` of reversed input. Should be `%v` but was `%v`", order, expected, Sort(reversed, order))
+		}
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	if o, err := ParseOrder("kind"); o != OrderKind || err != nil {
+		t.Errorf("Unexpected order. Should be `%s` but was `%s` (%v)", OrderKind, o, err)
+	}
+	if _, err := ParseOrder("size"); err == nil {
+		t.Errorf("Unexpected success of parsing unknown order")
+	}
+}
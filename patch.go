@@ -0,0 +1,124 @@
+package indiff
+
+// DefaultContextLines is count of unchanged lines shown around changes in patch
+const DefaultContextLines = 3
+
+// Operation identifies change of one line in patch
+type Operation string
+
+// Operations of lines in patch
+const (
+	LineEqual   Operation = "equal"
+	LineAdded   Operation = "added"
+	LineDeleted Operation = "deleted"
+)
+
+// Patch is structured representation of changes made to one file.
+// Nil patch means that changes are not known.
+type Patch struct {
+	Hunks []*Hunk `json:"hunks"`
+}
+
+// Hunk is continuous part of patch with changed lines and unchanged lines around them
+type Hunk struct {
+	// OldStart and OldLines define range of lines in original file
+	OldStart int `json:"oldStart"`
+	OldLines int `json:"oldLines"`
+	// NewStart and NewLines define range of lines in modified file
+	NewStart int     `json:"newStart"`
+	NewLines int     `json:"newLines"`
+	Lines    []*Line `json:"lines"`
+}
+
+// Line is one line of hunk
+type Line struct {
+	Op Operation `json:"op"`
+	// Content is text of line without line terminator
+	Content string `json:"content"`
+	// OldNumber is number of line in original file, it's zero for added line
+	OldNumber int `json:"oldNumber,omitempty"`
+	// NewNumber is number of line in modified file, it's zero for deleted line
+	NewNumber int `json:"newNumber,omitempty"`
+}
+
+// NewPatch creates patch from all lines of compared files. Changed lines are grouped to hunks
+// with given count of unchanged lines around them, close hunks are merged.
+func NewPatch(lines []*Line, contextLines int) *Patch {
+	// mark changed lines and their context
+	included := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == LineEqual {
+			continue
+		}
+		for j := i - contextLines; j <= i+contextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				included[j] = true
+			}
+		}
+	}
+
+	// each continuous block of marked lines is one hunk
+	patch := &Patch{Hunks: []*Hunk{}}
+	var current *Hunk
+	oldBefore, newBefore := 0, 0
+	for i, l := range lines {
+		if !included[i] {
+			current = nil
+		} else {
+			if current == nil {
+				current = &Hunk{OldStart: oldBefore, NewStart: newBefore}
+				patch.Hunks = append(patch.Hunks, current)
+			}
+			current.Lines = append(current.Lines, l)
+		}
+		oldCount, newCount := countLines([]*Line{l})
+		oldBefore += oldCount
+		newBefore += newCount
+	}
+
+	// ranges start at first line of hunk, empty range starts at line before hunk
+	for _, h := range patch.Hunks {
+		h.OldLines, h.NewLines = countLines(h.Lines)
+		if h.OldLines > 0 {
+			h.OldStart++
+		}
+		if h.NewLines > 0 {
+			h.NewStart++
+		}
+	}
+	return patch
+}
+
+// countLines returns count of lines in original and modified file
+func countLines(lines []*Line) (oldCount int, newCount int) {
+	for _, l := range lines {
+		if l.Op != LineAdded {
+			oldCount++
+		}
+		if l.Op != LineDeleted {
+			newCount++
+		}
+	}
+	return oldCount, newCount
+}
+
+// IsEmpty checks if patch has no changes, it's true for nil patch too
+func (p *Patch) IsEmpty() bool {
+	return p == nil || len(p.Hunks) == 0
+}
+
+// Lines returns lines of all hunks with given operation
+func (p *Patch) Lines(op Operation) []*Line {
+	if p == nil {
+		return nil
+	}
+	lines := []*Line{}
+	for _, h := range p.Hunks {
+		for _, l := range h.Lines {
+			if l.Op == op {
+				lines = append(lines, l)
+			}
+		}
+	}
+	return lines
+}
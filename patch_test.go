@@ -0,0 +1,65 @@
+package indiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+// numberedLines creates lines of patch from given operations, content is line number in modified file or "x" for deleted line
+func numberedLines(ops ...Operation) []*Line {
+	lines := []*Line{}
+	oldNumber, newNumber := 1, 1
+	for _, op := range ops {
+		l := &Line{Op: op, Content: "x"}
+		if op != LineAdded {
+			l.OldNumber = oldNumber
+			oldNumber++
+		}
+		if op != LineDeleted {
+			l.NewNumber = newNumber
+			newNumber++
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+func TestNewPatch(t *testing.T) {
+
+	// Given file with 20 lines where 2nd line is deleted, 10th line is added and 12th line is added
+	ops := []Operation{}
+	for i := 1; i <= 20; i++ {
+		switch i {
+		case 2:
+			ops = append(ops, LineDeleted)
+		case 10, 12:
+			ops = append(ops, LineAdded)
+		default:
+			ops = append(ops, LineEqual)
+		}
+	}
+	lines := numberedLines(ops...)
+
+	// When patch is created with 3 lines of context
+	patch := NewPatch(lines, 3)
+
+	// Then close changes should be in one hunk and distant change in another one
+	ranges := [][4]int{}
+	for _, h := range patch.Hunks {
+		ranges = append(ranges, [4]int{h.OldStart, h.OldLines, h.NewStart, h.NewLines})
+	}
+	expected := [][4]int{{1, 5, 1, 4}, {7, 7, 6, 9}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("Unexpected hunks. Should be `%v` but was `%v`", expected, ranges)
+	}
+	if len(patch.Hunks) == 2 && !reflect.DeepEqual(patch.Hunks[1].Lines, lines[6:15]) {
+		t.Errorf("Unexpected lines of second hunk. Should be `%v` but was `%v`", lines[6:15], patch.Hunks[1].Lines)
+	}
+}
+
+func TestNewPatchWithoutChanges(t *testing.T) {
+	patch := NewPatch(numberedLines(LineEqual, LineEqual), 3)
+	if !patch.IsEmpty() {
+		t.Errorf("Unexpected patch. Should be empty but was `%v`", patch.Hunks)
+	}
+}
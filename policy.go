@@ -0,0 +1,66 @@
+package indiff
+
+import "fmt"
+
+// Policy decides when found differences should be considered as failure
+type Policy struct {
+	// Kinds of differences considered as failure. When empty, no difference is failure.
+	Kinds []Kind
+	// Langs limits failures to differences and coverage in these languages. When empty, all languages are checked.
+	Langs []string
+	// MinCoverage is minimal percentage of base files which must have translation in each language. Zero disables check.
+	MinCoverage float64
+}
+
+// Violations returns human readable descriptions of all policy violations by given diffs and bundle
+func (p *Policy) Violations(bundle *Bundle, langs []string, diffs Diffs) []string {
+	violations := []string{}
+
+	// check diffs
+	counts := map[Kind]int{}
+	for _, d := range diffs {
+		if p.isLangChecked(d.Lang()) && p.isKindChecked(d.Kind()) {
+			counts[d.Kind()]++
+		}
+	}
+	for _, k := range p.Kinds {
+		if counts[k] > 0 {
+			violations = append(violations, fmt.Sprintf("found %d difference(s) of kind %s", counts[k], k))
+		}
+	}
+
+	// check coverage
+	if p.MinCoverage > 0 {
+		for _, lang := range langs {
+			if lang == bundle.BaseLang() || !p.isLangChecked(lang) {
+				continue
+			}
+			if coverage := bundle.Coverage(lang); coverage < p.MinCoverage {
+				violations = append(violations, fmt.Sprintf("coverage of %s is %.1f%% which is below %.1f%%", lang, coverage, p.MinCoverage))
+			}
+		}
+	}
+
+	return violations
+}
+
+func (p *Policy) isKindChecked(kind Kind) bool {
+	for _, k := range p.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) isLangChecked(lang string) bool {
+	if len(p.Langs) == 0 {
+		return true
+	}
+	for _, l := range p.Langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
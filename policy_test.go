@@ -0,0 +1,60 @@
+package indiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicyViolations(t *testing.T) {
+
+	// Given bundle with "en" as base language and missing translations in "de" and "sk"
+	bundle := NewBundle("en", Files{
+		NewFile("en/first.md", "en"),
+		NewFile("en/second.md", "en"),
+		NewFile("de/first.md", "de"),
+	})
+	langs := []string{"en", "de", "sk"}
+	diffs, err := NewBasic(langs).Diff(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Kinds", func(t *testing.T) {
+		// Given policy failing on modified files only
+		policy := &Policy{Kinds: []Kind{KindModifiedBase, KindModifiedBoth}}
+
+		// When policy is checked
+		violations := policy.Violations(bundle, langs, diffs)
+
+		// Then there should be no violation
+		assertViolations(t, []string{}, violations)
+	})
+
+	t.Run("Langs", func(t *testing.T) {
+		// Given policy failing on missing files in "sk"
+		policy := &Policy{Kinds: []Kind{KindMissing}, Langs: []string{"sk"}}
+
+		// When policy is checked
+		violations := policy.Violations(bundle, langs, diffs)
+
+		// Then only missing files in "sk" should be violation
+		assertViolations(t, []string{"found 2 difference(s) of kind missing"}, violations)
+	})
+
+	t.Run("MinCoverage", func(t *testing.T) {
+		// Given policy requiring half of files translated
+		policy := &Policy{MinCoverage: 50}
+
+		// When policy is checked
+		violations := policy.Violations(bundle, langs, diffs)
+
+		// Then only coverage of "sk" should be violation
+		assertViolations(t, []string{"coverage of sk is 0.0% which is below 50.0%"}, violations)
+	})
+}
+
+func assertViolations(t *testing.T, expected, violations []string) {
+	if !reflect.DeepEqual(expected, violations) {
+		t.Errorf("Unexpected violations. Should be `%v` but was `%v`", expected, violations)
+	}
+}
@@ -0,0 +1,158 @@
+package render
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// GitHub renderer is producing workflow commands which GitHub Actions show as annotations in pull request diff
+type GitHub struct {
+	// RootPath is top-level directory of repository, annotated paths must be relative to it
+	RootPath string
+	Order    indiff.Order
+}
+
+// Render prints one warning command per difference to given writer
+func (g *GitHub) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, g.Order)
+	for _, d := range diffs {
+		path := relativePath(g.RootPath, d.Base())
+		line, err := annotatedLine(d)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "::warning file=%s,line=%d,title=%s::%s\n",
+			escapeProperty(path), line, escapeProperty("indiff: "+string(d.Kind())), escapeData(describe(d, path, g.RootPath)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeData escapes message of workflow command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes property value of workflow command
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// GitLab renderer is producing Code Quality report which GitLab shows in merge request
+type GitLab struct {
+	// RootPath is top-level directory of repository, annotated paths must be relative to it
+	RootPath string
+	Order    indiff.Order
+}
+
+// codeQualityIssue is one issue of GitLab Code Quality report
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// Render prints given differences as JSON array of Code Quality issues to given writer
+func (g *GitLab) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, g.Order)
+	issues := make([]*codeQualityIssue, 0, len(diffs))
+	for _, d := range diffs {
+		path := relativePath(g.RootPath, d.Base())
+		line, err := annotatedLine(d)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, &codeQualityIssue{
+			Description: describe(d, path, g.RootPath),
+			CheckName:   "indiff-" + string(d.Kind()),
+			Fingerprint: fingerprint(d, path),
+			Severity:    "minor",
+			Location: codeQualityLocation{
+				Path:  path,
+				Lines: codeQualityLines{Begin: line},
+			},
+		})
+	}
+	content, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", content)
+	return err
+}
+
+// fingerprint identifies difference across runs, it does not depend on line number
+func fingerprint(d indiff.Diff, path string) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s:%s:%s", d.Kind(), d.Lang(), path)))
+	return hex.EncodeToString(sum[:])
+}
+
+// describe returns human readable message of given difference located in base file on given path
+func describe(d indiff.Diff, path string, root string) string {
+	translation := ""
+	if t := d.Translation(); t != nil {
+		translation = relativePath(root, t)
+	}
+	switch d.Kind() {
+	case indiff.KindMissing:
+		return fmt.Sprintf("%s: missing translation of: %s", d.Lang(), path)
+	case indiff.KindModifiedBase:
+		return fmt.Sprintf("%s: modified only base, translation should be updated: %s", d.Lang(), translation)
+	case indiff.KindModifiedBoth:
+		return fmt.Sprintf("%s: modified base and translation, check if translation is complete: %s", d.Lang(), translation)
+	case indiff.KindPossiblyStale:
+		return fmt.Sprintf("%s: possibly stale translation: %s", d.Lang(), translation)
+	default:
+		return fmt.Sprintf("%s: unknown difference: %s", d.Lang(), path)
+	}
+}
+
+// annotatedLine returns line of base file where difference should be shown.
+// It is first changed line of base patch for modifications and first line otherwise. Only base patch is loaded.
+func annotatedLine(d indiff.Diff) (int, error) {
+	patch, err := basePatch(d)
+	if err != nil {
+		return 1, err
+	}
+	return firstChangedLine(patch), nil
+}
+
+// firstChangedLine returns number of first added or removed line in modified file.
+// Removed line is reported at position where it was removed. It returns 1 when patch contains no change.
+func firstChangedLine(patch *indiff.Patch) int {
+	if patch.IsEmpty() {
+		return 1
+	}
+	for _, h := range patch.Hunks {
+		line := h.NewStart
+		for _, l := range h.Lines {
+			if l.Op != indiff.LineEqual {
+				if line < 1 {
+					return 1
+				}
+				return line
+			}
+			line++
+		}
+	}
+	return 1
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/internal/patchtest"
+)
+
+func TestFirstChangedLine(t *testing.T) {
+	tests := map[string]int{
+		"":                                       1,
+		"@@ -1,3 +1,4 @@\n a\n b\n+c\n d":        3,
+		"@@ -10,3 +12,2 @@\n a\n-b\n c":          13,
+		"@@ -0,0 +1,2 @@\n+a\n+b":                1,
+		"@@ -1,2 +0,0 @@\n-a\n-b":                1,
+		"@@ -5,1 +5,1 @@\n x\n@@ -20 +20 @@\n+y": 20,
+	}
+	for patch, expected := range tests {
+		// When
+		line := firstChangedLine(patchtest.MustParse(patch))
+
+		// Then
+		if line != expected {
+			t.Errorf("Unexpected line of patch `%s`. Should be `%d` but was `%d`", patch, expected, line)
+		}
+	}
+}
+
+func TestGitHubRender(t *testing.T) {
+	// Given
+	base := indiff.NewFile("/root/en/a,b.md", "en").Modified(patchtest.MustParse("@@ -1,2 +1,2 @@\n a\n-b\n+c"))
+	translation := indiff.NewFile("/root/de/a,b.md", "de")
+	diffs := indiff.Diffs{
+		indiff.NewModifiedBase(base, translation),
+		indiff.NewMissing(indiff.NewFile("/root/en/c.md", "en"), "de"),
+	}
+	g := &GitHub{RootPath: "/root"}
+
+	// When
+	out := &bytes.Buffer{}
+	err := g.Render(out, diffs)
+
+	// Then
+	expected := "::warning file=en/c.md,line=1,title=indiff%3A missing::de: missing translation of: en/c.md\n" +
+		"::warning file=en/a%2Cb.md,line=2,title=indiff%3A modified-base::de: modified only base, translation should be updated: de/a,b.md\n"
+	if err != nil || out.String() != expected {
+		t.Errorf("Unexpected output. Should be `%s` but was `%s` (%v)", expected, out.String(), err)
+	}
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/unravela/indiff"
+)
+
+// Checkstyle renderer is producing Checkstyle XML report with differences reported as warnings in base files
+type Checkstyle struct {
+	RootPath string
+	Order    indiff.Order
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Render prints given differences as Checkstyle XML to given writer.
+// Differences are grouped by base file in order of their first occurrence.
+func (c *Checkstyle) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, c.Order)
+	report := &checkstyleReport{Version: "4.3", Files: []*checkstyleFile{}}
+	files := map[string]*checkstyleFile{}
+	for _, d := range diffs {
+		path := relativePath(c.RootPath, d.Base())
+		f := files[path]
+		if f == nil {
+			f = &checkstyleFile{Name: path}
+			files[path] = f
+			report.Files = append(report.Files, f)
+		}
+		line, err := annotatedLine(d)
+		if err != nil {
+			return err
+		}
+		f.Errors = append(f.Errors, &checkstyleError{
+			Line:     line,
+			Severity: "warning",
+			Message:  describe(d, path, c.RootPath),
+			Source:   "indiff." + string(d.Kind()),
+		})
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
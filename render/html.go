@@ -0,0 +1,227 @@
+package render
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// HTML renderer is producing single static HTML page with summary per language
+// and expandable section with colored patches for each difference. Differences are grouped by language,
+// by kind or listed in one flat list depending on Order.
+type HTML struct {
+	RootPath          string
+	ShowRelativePaths bool
+	// ShowDiff enables expandable sections with patches, patches are not loaded without it
+	ShowDiff bool
+	// Efforts are rendered as table of translation effort when they are not nil
+	Efforts []*indiff.Effort
+	// LinkPrefix is prepended to file paths in links (e.g. URL of repository browser)
+	LinkPrefix string
+	Order      indiff.Order
+}
+
+// htmlPage is data model of HTML template
+type htmlPage struct {
+	Summary  []*htmlSummary
+	Efforts  []*indiff.Effort
+	Groups   []*htmlGroup
+	ShowDiff bool
+}
+
+// htmlSummary holds count of differences of each kind in one language
+type htmlSummary struct {
+	Lang   string
+	Counts map[indiff.Kind]int
+	Total  int
+}
+
+// htmlGroup holds differences in one language or of one kind, title is empty for flat list
+type htmlGroup struct {
+	ID    string
+	Title string
+	Diffs []*htmlDiff
+}
+
+// htmlDiff holds single difference prepared for rendering
+type htmlDiff struct {
+	Kind indiff.Kind
+	// Label is kind or language of difference which is not shown by title of its group
+	Label            string
+	Base             htmlFile
+	Translation      *htmlFile
+	BasePatch        []patchLine
+	TranslationPatch []patchLine
+	HasPatch         bool
+}
+
+// htmlFile is file path with link
+type htmlFile struct {
+	Path string
+	Link string
+}
+
+// Render prints given differences as HTML page to given writer
+func (h *HTML) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, h.Order)
+	page, err := h.page(diffs)
+	if err != nil {
+		return err
+	}
+	return htmlTemplate.Execute(out, page)
+}
+
+// page converts given differences to data model of template
+func (h *HTML) page(diffs indiff.Diffs) (*htmlPage, error) {
+	page := &htmlPage{Efforts: h.Efforts, ShowDiff: h.ShowDiff}
+	summaries := map[string]*htmlSummary{}
+	for _, d := range diffs {
+		if summaries[d.Lang()] == nil {
+			summaries[d.Lang()] = &htmlSummary{Lang: d.Lang(), Counts: map[indiff.Kind]int{}}
+			page.Summary = append(page.Summary, summaries[d.Lang()])
+		}
+		summaries[d.Lang()].Counts[d.Kind()]++
+		summaries[d.Lang()].Total++
+	}
+	sort.Slice(page.Summary, func(i, j int) bool { return page.Summary[i].Lang < page.Summary[j].Lang })
+
+	for _, group := range groupDiffs(diffs, h.Order) {
+		hg := &htmlGroup{Title: group.title}
+		switch h.Order {
+		case indiff.OrderKind:
+			hg.ID = "kind-" + group.title
+		case indiff.OrderLang, "":
+			hg.ID = "lang-" + group.title
+		}
+		for _, d := range group.diffs {
+			hd, err := h.diff(d)
+			if err != nil {
+				return nil, err
+			}
+			hg.Diffs = append(hg.Diffs, hd)
+		}
+		page.Groups = append(page.Groups, hg)
+	}
+	return page, nil
+}
+
+// diff converts given difference to data model of template, patches are loaded only when they are shown
+func (h *HTML) diff(d indiff.Diff) (*htmlDiff, error) {
+	hd := &htmlDiff{Kind: d.Kind(), Base: h.file(d.Base())}
+	switch h.Order {
+	case indiff.OrderKind:
+		hd.Label = d.Lang()
+	case indiff.OrderPath:
+		hd.Label = d.Lang() + " " + string(d.Kind())
+	default:
+		hd.Label = string(d.Kind())
+	}
+	if d.Translation() != nil {
+		t := h.file(d.Translation())
+		hd.Translation = &t
+	}
+	if h.ShowDiff {
+		files, err := modifiedFiles(d)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			hd.BasePatch = patchLines(files[0].patch)
+		}
+		if len(files) > 1 {
+			hd.TranslationPatch = patchLines(files[1].patch)
+		}
+		hd.HasPatch = len(hd.BasePatch) > 0 || len(hd.TranslationPatch) > 0
+	}
+	return hd, nil
+}
+
+// file creates link to given file, link is always relative to root whatever paths are displayed
+func (h *HTML) file(f *indiff.File) htmlFile {
+	path := displayPath(h.RootPath, h.ShowRelativePaths, f)
+	link := relativePath(h.RootPath, f)
+	if h.LinkPrefix != "" {
+		link = strings.TrimSuffix(h.LinkPrefix, "/") + "/" + strings.TrimPrefix(link, "/")
+	}
+	return htmlFile{Path: path, Link: link}
+}
+
+var htmlTemplate = template.Must(template.New("html").Funcs(template.FuncMap{
+	"kinds": func() []indiff.Kind { return indiff.Kinds },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Indiff report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table.summary { border-collapse: collapse; }
+table.summary th, table.summary td { border: 1px solid #ccc; padding: .3em .8em; text-align: right; }
+details, p.diff { margin: .3em 0; border: 1px solid #ddd; border-radius: 4px; padding: .3em .6em; }
+summary { cursor: pointer; }
+.label { display: inline-block; min-width: 9em; font-weight: bold; }
+.patches { display: flex; gap: 1em; margin-top: .5em; }
+.patch { flex: 1; overflow-x: auto; }
+pre { margin: 0; background: #f8f8f8; padding: .5em; }
+.add { background: #e6ffed; color: #22863a; }
+.del { background: #ffeef0; color: #b31d28; }
+.hunk { color: #6f42c1; }
+.num { display: inline-block; min-width: 3em; padding-right: .5em; text-align: right; color: #999; user-select: none; }
+</style>
+</head>
+<body>
+<h1>Indiff report</h1>
+{{- if not .Groups}}
+<p>No differences found.</p>
+{{- else}}
+<h2>Summary</h2>
+<table class="summary">
+<tr><th>Language</th>{{range kinds}}<th>{{.}}</th>{{end}}<th>total</th></tr>
+{{- range .Summary}}
+{{- $counts := .Counts}}
+<tr><td>{{.Lang}}</td>{{range kinds}}<td>{{index $counts .}}</td>{{end}}<td>{{.Total}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Efforts}}
+<h2>Translation effort</h2>
+<table class="summary">
+<tr><th>Language</th><th>words</th><th>characters</th></tr>
+{{- range .Efforts}}
+<tr><td>{{.Lang}}</td><td>{{.Words}}</td><td>{{.Characters}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- $showDiff := .ShowDiff}}
+{{- range .Groups}}
+{{- if .Title}}
+<h2 id="{{.ID}}">{{.Title}}</h2>
+{{- end}}
+{{- range .Diffs}}
+{{- if not $showDiff}}
+<p class="diff">{{template "files" .}}</p>
+{{- else}}
+<details>
+<summary>{{template "files" .}}</summary>
+{{- if .HasPatch}}
+<div class="patches">
+<div class="patch"><h4>Base</h4><pre>{{range .BasePatch}}{{template "line" .}}
+{{end}}</pre></div>
+<div class="patch"><h4>Translation</h4><pre>{{if .TranslationPatch}}{{range .TranslationPatch}}{{template "line" .}}
+{{end}}{{else}}not modified{{end}}</pre></div>
+</div>
+{{- else}}
+<p>{{if eq .Kind "missing"}}Translation does not exist.{{else}}No patch available.{{end}}</p>
+{{- end}}
+</details>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{- define "files"}}<span class="label">{{.Label}}</span> <a href="{{.Base.Link}}">{{.Base.Path}}</a>{{if .Translation}} &rarr; <a href="{{.Translation.Link}}">{{.Translation.Path}}</a>{{end}}{{end}}
+{{- define "line"}}<span class="num">{{if .OldNumber}}{{.OldNumber}}{{end}}</span><span class="num">{{if .NewNumber}}{{.NewNumber}}{{end}}</span><span class="{{.Class}}">{{.Text}}</span>{{end}}
+`))
@@ -0,0 +1,139 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// Markdown renderer is producing report suitable for pull request comments.
+// Differences are grouped according to Order with optional patches in collapsible blocks
+// and coverage summary table at the top.
+type Markdown struct {
+	RootPath          string
+	ShowRelativePaths bool
+	// ShowDiff enables patches, they are not loaded otherwise
+	ShowDiff bool
+	// Stats are used for coverage summary table, table is omitted when empty
+	Stats []*indiff.LangStats
+	// MaxSize limits size of output in bytes, zero means no limit
+	MaxSize int
+	Order   indiff.Order
+}
+
+// truncationNote is appended to output truncated because of MaxSize
+const truncationNote = "\n_Report was truncated because of its size._\n"
+
+// Render prints given differences as Markdown to given writer
+func (m *Markdown) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, m.Order)
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "## Translation report\n\n")
+	m.renderSummary(b)
+	summaryLen := b.Len()
+
+	if len(diffs) == 0 {
+		fmt.Fprintf(b, "No differences found.\n")
+	}
+	for _, group := range groupDiffs(diffs, m.Order) {
+		if group.title == "" {
+			// flat list of differences labeled by language and kind
+			for _, d := range group.diffs {
+				if err := m.renderDiff(b, fmt.Sprintf("%s %s: ", d.Lang(), d.Kind()), d); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintln(b)
+			continue
+		}
+		fmt.Fprintf(b, "### %s\n\n", group.title)
+		for _, sub := range group.groups {
+			fmt.Fprintf(b, "#### %s (%d)\n\n", sub.title, len(sub.diffs))
+			for _, d := range sub.diffs {
+				if err := m.renderDiff(b, "", d); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintln(b)
+		}
+	}
+
+	_, err := io.WriteString(out, m.truncate(b.String(), summaryLen))
+	return err
+}
+
+// renderSummary prints coverage table
+func (m *Markdown) renderSummary(b *strings.Builder) {
+	if len(m.Stats) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "| Language | Translated | Missing | Stale | Coverage |\n")
+	fmt.Fprintf(b, "|---|--:|--:|--:|--:|\n")
+	for _, s := range m.Stats {
+		fmt.Fprintf(b, "| %s | %d/%d | %d | %d | %.1f%% |\n", s.Lang, s.Translated, s.BaseFiles, s.Missing, s.Stale, s.Coverage)
+	}
+	fmt.Fprintln(b)
+}
+
+// renderDiff prints one difference as list item starting with given label, patches are in collapsible block
+func (m *Markdown) renderDiff(b *strings.Builder, label string, d indiff.Diff) error {
+	title := fmt.Sprintf("%s`%s`", label, m.resolve(d.Base()))
+	if t := d.Translation(); t != nil {
+		title += fmt.Sprintf(" → `%s`", m.resolve(t))
+	}
+	if !m.ShowDiff {
+		fmt.Fprintf(b, "- %s\n", title)
+		return nil
+	}
+
+	files, err := modifiedFiles(d)
+	if err != nil {
+		return err
+	}
+	var patches [][2]string
+	for _, f := range files {
+		patches = append(patches, [2]string{m.resolve(f.file), Unified(f.patch)})
+	}
+	if len(patches) == 0 || patches[0][1] == "" {
+		fmt.Fprintf(b, "- %s\n", title)
+		return nil
+	}
+
+	// markdown is not rendered inside HTML summary element
+	title = strings.Replace(strings.Replace(html.EscapeString(title), "`", "<code>", 1), "`", "</code>", 1)
+	title = strings.Replace(strings.Replace(title, "`", "<code>", 1), "`", "</code>", 1)
+	fmt.Fprintf(b, "- <details><summary>%s</summary>\n\n", title)
+	for _, p := range patches {
+		fmt.Fprintf(b, "  ```diff\n  diff %s\n", p[0])
+		for _, line := range strings.Split(p[1], "\n") {
+			fmt.Fprintf(b, "  %s\n", line)
+		}
+		fmt.Fprintf(b, "  ```\n")
+	}
+	fmt.Fprintf(b, "  </details>\n")
+	return nil
+}
+
+// truncate cuts given report to MaxSize at the end of some line, it never cuts summary
+func (m *Markdown) truncate(report string, summaryLen int) string {
+	if m.MaxSize <= 0 || len(report) <= m.MaxSize {
+		return report
+	}
+	limit := m.MaxSize - len(truncationNote)
+	if limit < summaryLen {
+		limit = summaryLen
+	}
+	cut := strings.LastIndex(report[:limit], "\n- ")
+	if cut < summaryLen {
+		cut = summaryLen
+	}
+	return report[:cut] + "\n" + truncationNote
+}
+
+// resolve converts path of given file to relative path if requested and possible otherwise full path is returned
+func (m *Markdown) resolve(file *indiff.File) string {
+	return displayPath(m.RootPath, m.ShowRelativePaths, file)
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// patchLine is one line of patch with its type used for coloring
+type patchLine struct {
+	// Class is one of hunk, add, del or ctx
+	Class string
+	Text  string
+	// OldNumber and NewNumber are line numbers in original and modified file, zero when line is not there
+	OldNumber int
+	NewNumber int
+}
+
+// modifiedFile is file of difference with changes made to it
+type modifiedFile struct {
+	file  *indiff.File
+	patch *indiff.Patch
+}
+
+// modifiedFiles returns modified files of given difference with their patches, base file is first.
+// It returns no files for difference without modifications and error when some patch can't be loaded.
+func modifiedFiles(d indiff.Diff) ([]modifiedFile, error) {
+	switch diff := d.(type) {
+	case *indiff.ModifiedBase:
+		base, err := diff.BasePatch()
+		if err != nil {
+			return nil, err
+		}
+		return []modifiedFile{{diff.Base(), base}}, nil
+	case *indiff.ModifiedBoth:
+		base, err := diff.BasePatch()
+		if err != nil {
+			return nil, err
+		}
+		translation, err := diff.TranslationPatch()
+		if err != nil {
+			return nil, err
+		}
+		return []modifiedFile{{diff.Base(), base}, {diff.Translation(), translation}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// basePatch returns patch of base file of given difference or nil when base file was not modified
+func basePatch(d indiff.Diff) (*indiff.Patch, error) {
+	switch diff := d.(type) {
+	case *indiff.ModifiedBase:
+		return diff.BasePatch()
+	case *indiff.ModifiedBoth:
+		return diff.BasePatch()
+	default:
+		return nil, nil
+	}
+}
+
+// translationPatch returns patch of translation file of given difference or nil when translation was not modified
+func translationPatch(d indiff.Diff) (*indiff.Patch, error) {
+	if diff, ok := d.(*indiff.ModifiedBoth); ok {
+		return diff.TranslationPatch()
+	}
+	return nil, nil
+}
+
+// Unified returns text of given patch in unified format without file headers.
+// It returns empty string for empty patch.
+func Unified(patch *indiff.Patch) string {
+	lines := []string{}
+	for _, l := range patchLines(patch) {
+		lines = append(lines, l.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// patchLines converts given patch to lines with hunk headers and operation prefixes
+func patchLines(patch *indiff.Patch) []patchLine {
+	if patch.IsEmpty() {
+		return nil
+	}
+	lines := []patchLine{}
+	for _, h := range patch.Hunks {
+		lines = append(lines, patchLine{Class: "hunk", Text: hunkHeader(h)})
+		for _, l := range h.Lines {
+			pl := patchLine{Class: "ctx", Text: " " + l.Content, OldNumber: l.OldNumber, NewNumber: l.NewNumber}
+			switch l.Op {
+			case indiff.LineAdded:
+				pl.Class, pl.Text = "add", "+"+l.Content
+			case indiff.LineDeleted:
+				pl.Class, pl.Text = "del", "-"+l.Content
+			}
+			lines = append(lines, pl)
+		}
+	}
+	return lines
+}
+
+// hunkHeader returns header of hunk with ranges of lines, count is omitted when range has one line
+func hunkHeader(h *indiff.Hunk) string {
+	return fmt.Sprintf("@@ -%s +%s @@", lineRange(h.OldStart, h.OldLines), lineRange(h.NewStart, h.NewLines))
+}
+
+func lineRange(start int, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -3,7 +3,6 @@ package render
 import (
 	"fmt"
 	"io"
-	"path/filepath"
 
 	"github.com/unravela/indiff"
 )
@@ -13,46 +12,57 @@ type Plain struct {
 	RootPath          string
 	ShowRelativePaths bool
 	ShowDiff          bool
+	Order             indiff.Order
 }
 
-// Render prints given differences as simple text with one line per difference to given writer
-func (p *Plain) Render(out io.Writer, diffs indiff.Diffs) {
+// Render prints given differences as simple text with one line per difference to given writer.
+// It stops on first patch which can't be loaded.
+func (p *Plain) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, p.Order)
 	for _, d := range diffs {
 		switch diff := d.(type) {
 		case *indiff.Missing:
 			fmt.Fprintf(out, "%s: missing translation of: %s\n", diff.Lang(), p.resolve(diff.Base()))
 		case *indiff.ModifiedBase:
 			fmt.Fprintf(out, "%s: modified only base: %s: %s\n", diff.Lang(), p.resolve(diff.Base()), p.resolve(diff.Translation()))
-			p.renderDiff(out, diff.Base(), diff.BasePatch())
 		case *indiff.ModifiedBoth:
 			fmt.Fprintf(out, "%s: modified base and translation: %s: %s\n", diff.Lang(), p.resolve(diff.Base()), p.resolve(diff.Translation()))
-			p.renderDiff(out, diff.Base(), diff.BasePatch())
-			p.renderDiff(out, diff.Translation(), diff.TranslationPatch())
+		case *indiff.PossiblyStale:
+			fmt.Fprintf(out, "%s: possibly stale translation: %s: %s\n", diff.Lang(), p.resolve(diff.Base()), p.resolve(diff.Translation()))
 		default:
 			fmt.Fprintf(out, "%s: unknown difference: %s: %s\n", diff.Lang(), p.resolve(diff.Base()), p.resolve(diff.Translation()))
 		}
+		if !p.ShowDiff {
+			continue
+		}
+		files, err := modifiedFiles(d)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			p.renderDiff(out, f.file, f.patch)
+		}
 	}
+	return nil
 }
 
 // renderDiff prints changes made in file
-func (p *Plain) renderDiff(out io.Writer, f *indiff.File, patch string) {
-	if p.ShowDiff {
-		patchText := patch
-		if patchText == "" {
-			patchText = "<no content>"
-		}
-		fmt.Fprintf(out, "diff %s\n%s\n", p.resolve(f), patchText)
+func (p *Plain) renderDiff(out io.Writer, f *indiff.File, patch *indiff.Patch) {
+	patchText := Unified(patch)
+	if patchText == "" {
+		patchText = "<no content>"
 	}
+	fmt.Fprintf(out, "diff %s\n%s\n", p.resolve(f), patchText)
 }
 
 // resolve converts path of given file to relative path if requested and possible otherwise full path is returned
 func (p *Plain) resolve(file *indiff.File) string {
-	if !p.ShowRelativePaths {
-		return file.Path
-	}
-	rel, err := filepath.Rel(p.RootPath, file.Path)
-	if err != nil {
-		return file.Path
+	return displayPath(p.RootPath, p.ShowRelativePaths, file)
+}
+
+// RenderEffort prints amount of text to translate with one line per language to given writer
+func (p *Plain) RenderEffort(out io.Writer, efforts []*indiff.Effort) {
+	for _, e := range efforts {
+		fmt.Fprintf(out, "%s: translation effort: %d words, %d characters\n", e.Lang, e.Words, e.Characters)
 	}
-	return rel
 }
@@ -0,0 +1,168 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// Pretty renderer is producing terminal output grouped according to Order with optional ANSI colors
+// and summary footer
+type Pretty struct {
+	RootPath          string
+	ShowRelativePaths bool
+	ShowDiff          bool
+	// Color enables ANSI colors, see ColorSupported
+	Color bool
+	Order indiff.Order
+}
+
+// ANSI escape sequences used by Pretty renderer
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+// kindColors are colors of kind headers
+var kindColors = map[indiff.Kind]string{
+	indiff.KindMissing:       ansiRed,
+	indiff.KindModifiedBase:  ansiYellow,
+	indiff.KindModifiedBoth:  ansiMagenta,
+	indiff.KindPossiblyStale: ansiCyan,
+}
+
+// ColorSupported checks if colors should be used for output to given file.
+// Colors are disabled when NO_COLOR environment variable is set to non-empty value or file is not a terminal.
+func ColorSupported(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Render prints given differences to given writer. They are grouped by language and kind,
+// by kind and language or listed in one flat list depending on Order.
+func (p *Pretty) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, p.Order)
+	b := &strings.Builder{}
+	counts := map[indiff.Kind]int{}
+	for _, d := range diffs {
+		counts[d.Kind()]++
+	}
+	for _, group := range groupDiffs(diffs, p.Order) {
+		if group.title == "" {
+			// flat list of differences labeled by language and kind
+			for _, d := range group.diffs {
+				label := fmt.Sprintf("  %s %s: ", d.Lang(), p.color(kindColors[d.Kind()], string(d.Kind())))
+				if err := p.renderDiff(b, label, d); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintln(b)
+			continue
+		}
+		fmt.Fprintf(b, "%s\n", p.title(group.title, ""))
+		for _, sub := range group.groups {
+			fmt.Fprintf(b, "  %s\n", p.title(sub.title, fmt.Sprintf(" (%d)", len(sub.diffs))))
+			for _, d := range sub.diffs {
+				if err := p.renderDiff(b, "    ", d); err != nil {
+					return err
+				}
+			}
+		}
+		fmt.Fprintln(b)
+	}
+	p.renderFooter(b, len(diffs), countLangs(diffs), counts)
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// title colors given group title, kinds use their colors and languages are bold
+func (p *Pretty) title(title string, suffix string) string {
+	if code, ok := kindColors[indiff.Kind(title)]; ok {
+		return p.color(code, title+suffix)
+	}
+	return p.color(ansiBold, title+suffix)
+}
+
+// renderDiff prints paths of one difference after given prefix with its patches
+func (p *Pretty) renderDiff(b *strings.Builder, prefix string, d indiff.Diff) error {
+	if t := d.Translation(); t != nil {
+		fmt.Fprintf(b, "%s%s → %s\n", prefix, p.resolve(d.Base()), p.resolve(t))
+	} else {
+		fmt.Fprintf(b, "%s%s\n", prefix, p.resolve(d.Base()))
+	}
+	if !p.ShowDiff {
+		return nil
+	}
+	files, err := modifiedFiles(d)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		p.renderPatch(b, f.file, f.patch)
+	}
+	return nil
+}
+
+// renderPatch prints colored lines of patch
+func (p *Pretty) renderPatch(b *strings.Builder, f *indiff.File, patch *indiff.Patch) {
+	fmt.Fprintf(b, "      %s\n", p.color(ansiBold, "diff "+p.resolve(f)))
+	if patch.IsEmpty() {
+		fmt.Fprintf(b, "      <no content>\n")
+		return
+	}
+	for _, l := range patchLines(patch) {
+		switch l.Class {
+		case "hunk":
+			fmt.Fprintf(b, "      %s\n", p.color(ansiCyan, l.Text))
+		case "add":
+			fmt.Fprintf(b, "      %s\n", p.color(ansiGreen, l.Text))
+		case "del":
+			fmt.Fprintf(b, "      %s\n", p.color(ansiRed, l.Text))
+		default:
+			fmt.Fprintf(b, "      %s\n", l.Text)
+		}
+	}
+}
+
+// renderFooter prints summary with count of differences of each kind
+func (p *Pretty) renderFooter(b *strings.Builder, total int, langs int, counts map[indiff.Kind]int) {
+	if total == 0 {
+		fmt.Fprintf(b, "%s\n", p.color(ansiGreen, "No differences found."))
+		return
+	}
+	parts := []string{}
+	for _, kind := range indiff.Kinds {
+		if counts[kind] > 0 {
+			parts = append(parts, p.color(kindColors[kind], fmt.Sprintf("%d %s", counts[kind], kind)))
+		}
+	}
+	fmt.Fprintf(b, "%s %s\n", p.color(ansiBold, fmt.Sprintf("Found %d difference(s) in %d language(s):", total, langs)), strings.Join(parts, ", "))
+}
+
+// color wraps given text to ANSI color sequence when colors are enabled
+func (p *Pretty) color(code string, text string) string {
+	if !p.Color {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// resolve converts path of given file to relative path if requested and possible otherwise full path is returned
+func (p *Pretty) resolve(file *indiff.File) string {
+	return displayPath(p.RootPath, p.ShowRelativePaths, file)
+}
@@ -0,0 +1,99 @@
+package render
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/unravela/indiff"
+)
+
+// Renderer prints differences to writer in some output format.
+// Built-in renderers sort differences by their Order field (see indiff.Order), so output is stable across runs.
+type Renderer interface {
+	Render(out io.Writer, diffs indiff.Diffs) error
+}
+
+var (
+	_ Renderer = &Plain{}
+	_ Renderer = &HTML{}
+	_ Renderer = &Markdown{}
+	_ Renderer = &GitHub{}
+	_ Renderer = &GitLab{}
+	_ Renderer = &Checkstyle{}
+	_ Renderer = &TAP{}
+	_ Renderer = &Pretty{}
+	_ Renderer = &Template{}
+)
+
+// displayPath returns path of given file relative to root when relative path is requested and possible,
+// otherwise full path is returned
+func displayPath(root string, relative bool, file *indiff.File) string {
+	if !relative {
+		return file.Path
+	}
+	rel, err := filepath.Rel(root, file.Path)
+	if err != nil {
+		return file.Path
+	}
+	return rel
+}
+
+// relativePath returns slash separated path of given file relative to root or full path when it is not possible
+func relativePath(root string, file *indiff.File) string {
+	return filepath.ToSlash(displayPath(root, true, file))
+}
+
+// diffGroup holds differences with same value of first criterion of order (language or kind),
+// leaf groups have no subgroups
+type diffGroup struct {
+	// title is language or kind shared by differences in group, it's empty for flat list
+	title  string
+	groups []*diffGroup
+	diffs  indiff.Diffs
+}
+
+// groupDiffs groups given differences sorted in given order. Differences are grouped by language and kind
+// for OrderLang, by kind and language for OrderKind and they form single flat group for OrderPath.
+func groupDiffs(diffs indiff.Diffs, order indiff.Order) []*diffGroup {
+	byLang := func(d indiff.Diff) string { return d.Lang() }
+	byKind := func(d indiff.Diff) string { return string(d.Kind()) }
+	switch order {
+	case indiff.OrderPath:
+		if len(diffs) == 0 {
+			return nil
+		}
+		return []*diffGroup{{diffs: diffs}}
+	case indiff.OrderKind:
+		return splitDiffs(diffs, byKind, byLang)
+	default:
+		return splitDiffs(diffs, byLang, byKind)
+	}
+}
+
+// splitDiffs splits sorted differences to groups by first key and each group to subgroups by remaining keys
+func splitDiffs(diffs indiff.Diffs, keys ...func(indiff.Diff) string) []*diffGroup {
+	var groups []*diffGroup
+	for _, d := range diffs {
+		key := keys[0](d)
+		if len(groups) == 0 || groups[len(groups)-1].title != key {
+			groups = append(groups, &diffGroup{title: key})
+		}
+		g := groups[len(groups)-1]
+		g.diffs = append(g.diffs, d)
+	}
+	if len(keys) > 1 {
+		for _, g := range groups {
+			g.groups = splitDiffs(g.diffs, keys[1:]...)
+		}
+	}
+	return groups
+}
+
+// countLangs returns number of distinct languages of given differences
+func countLangs(diffs indiff.Diffs) int {
+	langs := map[string]bool{}
+	for _, d := range diffs {
+		langs[d.Lang()] = true
+	}
+	return len(langs)
+}
@@ -0,0 +1,271 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/internal/patchtest"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// testDiffs returns differences of all kinds used by golden file tests
+func testDiffs() indiff.Diffs {
+	return indiff.Diffs{
+		indiff.NewMissing(indiff.NewFile("/doc/en/guide.md", "en"), "de"),
+		indiff.NewModifiedBase(
+			indiff.NewFile("/doc/en/index.md", "en").Modified(patchtest.MustParse("@@ -1,3 +1,3 @@\n # Title\n-Old text\n+New <text> & more\n end")),
+			indiff.NewFile("/doc/de/index.md", "de"),
+		),
+		indiff.NewModifiedBoth(
+			indiff.NewFile("/doc/en/index.md", "en").Modified(patchtest.MustParse("@@ -4,2 +4,3 @@\n a\n b\n+c")),
+			indiff.NewFile("/doc/sk/index.md", "sk").Modified(patchtest.MustParse("@@ -4,2 +4,2 @@\n a\n-b\n+x")),
+		),
+		indiff.NewPossiblyStale(indiff.NewFile("/doc/en/faq #1.md", "en"), indiff.NewFile("/doc/sk/faq #1.md", "sk")),
+	}
+}
+
+// reversedDiffs returns test differences with missing translation to sk in reversed order
+func reversedDiffs() indiff.Diffs {
+	diffs := append(testDiffs(), indiff.NewMissing(indiff.NewFile("/doc/en/guide.md", "en"), "sk"))
+	reversed := indiff.Diffs{}
+	for i := len(diffs) - 1; i >= 0; i-- {
+		reversed = append(reversed, diffs[i])
+	}
+	return reversed
+}
+
+// assertGolden compares output of given renderer with content of golden file
+func assertGolden(t *testing.T, r Renderer, diffs indiff.Diffs, golden string) {
+	out := &bytes.Buffer{}
+	if err := r.Render(out, diffs); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	path := filepath.Join("testdata", golden)
+	if *update {
+		if err := ioutil.WriteFile(path, out.Bytes(), 0644); err != nil {
+			t.Fatalf("Unable to update golden file: %s", err)
+		}
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unable to read golden file: %s", err)
+	}
+	if !bytes.Equal(expected, out.Bytes()) {
+		t.Errorf("Unexpected output of %s. Should be `%s` but was `%s`", golden, expected, out.String())
+	}
+}
+
+func TestCheckstyleGolden(t *testing.T) {
+	assertGolden(t, &Checkstyle{RootPath: "/doc"}, testDiffs(), "checkstyle.golden")
+	assertGolden(t, &Checkstyle{RootPath: "/doc"}, indiff.Diffs{}, "checkstyle-empty.golden")
+}
+
+func TestTAPGolden(t *testing.T) {
+	assertGolden(t, &TAP{RootPath: "/doc"}, testDiffs(), "tap.golden")
+	assertGolden(t, &TAP{RootPath: "/doc"}, indiff.Diffs{}, "tap-empty.golden")
+}
+
+func TestTemplateGolden(t *testing.T) {
+	tmpl := &Template{
+		Path:     filepath.Join("testdata", "report.tmpl"),
+		RootPath: "/doc",
+		BaseLang: "en",
+		From:     "v1.0.0",
+		To:       "HEAD",
+		Stats:    []*indiff.LangStats{{Lang: "de", Coverage: 50}, {Lang: "sk", Coverage: 100}},
+	}
+	assertGolden(t, tmpl, testDiffs(), "template.golden")
+}
+
+func TestTemplateInvalid(t *testing.T) {
+	// Given template renderer with path to file which does not exist
+	tmpl := &Template{Path: filepath.Join("testdata", "missing.tmpl")}
+
+	// When differences are rendered
+	out := &bytes.Buffer{}
+	err := tmpl.Render(out, testDiffs())
+
+	// Then rendering should fail without any output
+	if err == nil || out.Len() != 0 {
+		t.Errorf("Unexpected result. Should fail without output but was `%s` (%v)", out.String(), err)
+	}
+}
+
+func TestHTMLGolden(t *testing.T) {
+	efforts := []*indiff.Effort{{Lang: "de", Words: 120, Characters: 640}}
+	assertGolden(t, &HTML{RootPath: "/doc", ShowRelativePaths: true, ShowDiff: true, LinkPrefix: "https://example.com/doc"}, testDiffs(), "html.golden")
+	assertGolden(t, &HTML{RootPath: "/doc", ShowRelativePaths: true, Efforts: efforts}, testDiffs(), "html-nodiff.golden")
+	assertGolden(t, &HTML{RootPath: "/doc", LinkPrefix: "https://example.com/doc"}, testDiffs(), "html-absolute.golden")
+	assertGolden(t, &HTML{RootPath: "/doc"}, indiff.Diffs{}, "html-empty.golden")
+}
+
+func TestMarkdownGolden(t *testing.T) {
+	stats := []*indiff.LangStats{{Lang: "de", BaseFiles: 3, Translated: 2, Missing: 1, Stale: 1, Coverage: 66.7}}
+	assertGolden(t, &Markdown{RootPath: "/doc", ShowRelativePaths: true, ShowDiff: true, Stats: stats}, testDiffs(), "markdown.golden")
+	assertGolden(t, &Markdown{RootPath: "/doc", ShowRelativePaths: true}, indiff.Diffs{}, "markdown-empty.golden")
+}
+
+func TestMarkdownTruncated(t *testing.T) {
+	// Given renderer with limit smaller than whole report
+	stats := []*indiff.LangStats{{Lang: "de", BaseFiles: 3, Translated: 2, Missing: 1, Stale: 1, Coverage: 66.7}}
+	m := &Markdown{RootPath: "/doc", ShowRelativePaths: true, ShowDiff: true, Stats: stats, MaxSize: 300}
+
+	// When differences are rendered
+	out := &bytes.Buffer{}
+	if err := m.Render(out, testDiffs()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then report should be cut at the end of list item within limit and keep summary
+	if out.Len() > m.MaxSize || !strings.HasSuffix(out.String(), truncationNote) || !strings.Contains(out.String(), "| de | 2/3 |") {
+		t.Errorf("Unexpected truncated report of size %d: `%s`", out.Len(), out.String())
+	}
+	assertGolden(t, m, testDiffs(), "markdown-truncated.golden")
+}
+
+func TestPrettyGolden(t *testing.T) {
+	assertGolden(t, &Pretty{RootPath: "/doc", ShowRelativePaths: true, ShowDiff: true}, testDiffs(), "pretty.golden")
+	assertGolden(t, &Pretty{RootPath: "/doc", ShowRelativePaths: true, ShowDiff: true, Color: true}, testDiffs(), "pretty-color.golden")
+	assertGolden(t, &Pretty{RootPath: "/doc"}, indiff.Diffs{}, "pretty-empty.golden")
+
+	// differences in reversed order should be sorted to same output
+	diffs := testDiffs()
+	for i, j := 0, len(diffs)-1; i < j; i, j = i+1, j-1 {
+		diffs[i], diffs[j] = diffs[j], diffs[i]
+	}
+	assertGolden(t, &Pretty{RootPath: "/doc", ShowRelativePaths: true, ShowDiff: true}, diffs, "pretty.golden")
+}
+
+func TestColorSupported(t *testing.T) {
+	// Given character device
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		t.Skip("Null device is not character device")
+	}
+	defer os.Setenv("NO_COLOR", os.Getenv("NO_COLOR"))
+
+	tests := map[string]bool{"": true, "1": false}
+	for value, expected := range tests {
+		// When NO_COLOR is set
+		os.Setenv("NO_COLOR", value)
+		supported := ColorSupported(f)
+
+		// Then colors should be disabled only by non-empty value
+		if supported != expected {
+			t.Errorf("Unexpected result for NO_COLOR=`%s`. Should be `%t` but was `%t`", value, expected, supported)
+		}
+	}
+}
+
+func TestPlainOrder(t *testing.T) {
+	// Given differences in reversed order where missing translations are in both languages, so each order differs
+	diffs := reversedDiffs()
+
+	// Then
+	assertGolden(t, &Plain{RootPath: "/doc", ShowRelativePaths: true}, diffs, "plain.golden")
+	assertGolden(t, &Plain{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderKind}, diffs, "plain-kind.golden")
+	assertGolden(t, &Plain{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderPath}, diffs, "plain-path.golden")
+}
+
+func TestPrettyOrder(t *testing.T) {
+	// Given differences in reversed order where missing translations are in both languages, so each order differs
+	diffs := reversedDiffs()
+
+	// Then differences should be grouped by language, by kind or listed in flat list
+	assertGolden(t, &Pretty{RootPath: "/doc", ShowRelativePaths: true}, diffs, "pretty-lang.golden")
+	assertGolden(t, &Pretty{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderKind}, diffs, "pretty-kind.golden")
+	assertGolden(t, &Pretty{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderPath}, diffs, "pretty-path.golden")
+}
+
+func TestMarkdownOrder(t *testing.T) {
+	// Given differences in reversed order where missing translations are in both languages, so each order differs
+	diffs := reversedDiffs()
+
+	// Then differences should be grouped by language, by kind or listed in flat list
+	assertGolden(t, &Markdown{RootPath: "/doc", ShowRelativePaths: true}, diffs, "markdown-lang.golden")
+	assertGolden(t, &Markdown{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderKind}, diffs, "markdown-kind.golden")
+	assertGolden(t, &Markdown{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderPath}, diffs, "markdown-path.golden")
+}
+
+func TestHTMLOrder(t *testing.T) {
+	// Given differences in reversed order where missing translations are in both languages, so each order differs
+	diffs := reversedDiffs()
+
+	// Then differences should be grouped by language, by kind or listed in flat list
+	assertGolden(t, &HTML{RootPath: "/doc", ShowRelativePaths: true}, diffs, "html-lang.golden")
+	assertGolden(t, &HTML{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderKind}, diffs, "html-kind.golden")
+	assertGolden(t, &HTML{RootPath: "/doc", ShowRelativePaths: true, Order: indiff.OrderPath}, diffs, "html-path.golden")
+}
+
+func TestRenderPatchFailure(t *testing.T) {
+	// Given modification which patch can't be loaded
+	failure := &indiff.GitError{Revision: "HEAD", Path: "en/index.md", Err: fmt.Errorf("object not found")}
+	base := indiff.NewLazyModification(indiff.NewFile("/doc/en/index.md", "en"), func() (*indiff.Patch, error) {
+		return nil, failure
+	})
+	diffs := indiff.Diffs{indiff.NewModifiedBase(base, indiff.NewFile("/doc/de/index.md", "de"))}
+
+	renderers := map[string]Renderer{
+		"plain":      &Plain{ShowDiff: true},
+		"pretty":     &Pretty{ShowDiff: true},
+		"html":       &HTML{ShowDiff: true},
+		"markdown":   &Markdown{ShowDiff: true},
+		"github":     &GitHub{},
+		"gitlab":     &GitLab{},
+		"checkstyle": &Checkstyle{},
+		"tap":        &TAP{},
+		"template":   &Template{Path: filepath.Join("testdata", "report.tmpl")},
+	}
+	for name, r := range renderers {
+		t.Run(name, func(t *testing.T) {
+			// When differences are rendered
+			err := r.Render(&bytes.Buffer{}, diffs)
+
+			// Then failure of patch should be returned
+			if !errors.Is(err, failure) {
+				t.Errorf("Unexpected error. Should be `%v` but was `%v`", failure, err)
+			}
+		})
+	}
+}
+
+func TestRenderWithoutPatches(t *testing.T) {
+	// Given modification which patch fails when it is loaded, renderers of annotations need it for line
+	base := indiff.NewLazyModification(indiff.NewFile("/doc/en/index.md", "en"), func() (*indiff.Patch, error) {
+		return nil, fmt.Errorf("patch should not be loaded")
+	})
+	diffs := indiff.Diffs{indiff.NewModifiedBase(base, indiff.NewFile("/doc/de/index.md", "de"))}
+
+	renderers := map[string]Renderer{
+		"plain":    &Plain{},
+		"pretty":   &Pretty{},
+		"html":     &HTML{},
+		"markdown": &Markdown{},
+		"template": &Template{Path: filepath.Join("testdata", "paths.tmpl")},
+	}
+	for name, r := range renderers {
+		t.Run(name, func(t *testing.T) {
+			// When differences are rendered without patches
+			err := r.Render(&bytes.Buffer{}, diffs)
+
+			// Then patch should not be loaded
+			if err != nil {
+				t.Errorf("Unexpected error. Should be `nil` but was `%v`", err)
+			}
+		})
+	}
+}
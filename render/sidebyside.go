@@ -0,0 +1,43 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// SideBySide prints two texts in two columns of given total width separated by vertical line.
+// Too long lines are truncated, tabs are replaced by spaces and trailing spaces are removed.
+func SideBySide(out io.Writer, left string, right string, width int) {
+	colWidth := (width - 3) / 2
+	if colWidth < 10 {
+		colWidth = 10
+	}
+	leftLines := strings.Split(strings.TrimRight(left, "\n"), "\n")
+	rightLines := strings.Split(strings.TrimRight(right, "\n"), "\n")
+	rows := len(leftLines)
+	if len(rightLines) > rows {
+		rows = len(rightLines)
+	}
+	for i := 0; i < rows; i++ {
+		l, r := "", ""
+		if i < len(leftLines) {
+			l = leftLines[i]
+		}
+		if i < len(rightLines) {
+			r = rightLines[i]
+		}
+		fmt.Fprintln(out, strings.TrimRight(fitColumn(l, colWidth)+" | "+fitColumn(r, colWidth), " "))
+	}
+}
+
+// fitColumn truncates or pads given line to exactly given width
+func fitColumn(line string, width int) string {
+	line = strings.Replace(line, "\t", "    ", -1)
+	if n := utf8.RuneCountInString(line); n <= width {
+		return line + strings.Repeat(" ", width-n)
+	}
+	runes := []rune(line)
+	return string(runes[:width-1]) + "…"
+}
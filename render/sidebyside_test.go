@@ -0,0 +1,37 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSideBySide(t *testing.T) {
+
+	// Given texts with different count of lines, tab and too long line
+	left := "# First\n\tindented\nvery long line which does not fit\n"
+	right := "# Erste\n"
+
+	// When texts are printed side by side
+	out := &bytes.Buffer{}
+	SideBySide(out, left, right, 27)
+
+	// Then columns should have same width with expanded tab and truncated line
+	expected := "# First      | # Erste\n" +
+		"    indented |\n" +
+		"very long l… |\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected output. Should be `%q` but was `%q`", expected, out.String())
+	}
+}
+
+func TestSideBySideMinimalWidth(t *testing.T) {
+
+	// When texts are printed in too narrow space
+	out := &bytes.Buffer{}
+	SideBySide(out, "ěščřžýáíéůú", "right", 5)
+
+	// Then columns should have minimal width counted in characters
+	if expected := "ěščřžýáíé… | right\n"; out.String() != expected {
+		t.Errorf("Unexpected output. Should be `%q` but was `%q`", expected, out.String())
+	}
+}
@@ -0,0 +1,40 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/unravela/indiff"
+)
+
+// RenderStats prints given statistics as table with one row per language to given writer
+func (p *Plain) RenderStats(out io.Writer, stats []*indiff.LangStats) {
+	showWords := len(stats) > 0 && stats[0].Words != nil
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "LANG\tBASE\tTRANSLATED\tMISSING\tSTALE\tCOVERAGE\t")
+	if showWords {
+		fmt.Fprint(w, "WORDS\t")
+	}
+	fmt.Fprintln(w)
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f%%\t", s.Lang, s.BaseFiles, s.Translated, s.Missing, s.Stale, s.Coverage)
+		if showWords {
+			fmt.Fprintf(w, "%d\t", *s.Words)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
+
+// StatsJSON renderer is producing statistics in JSON format, it does not render differences
+type StatsJSON struct{}
+
+// RenderStats prints given statistics as JSON array with one object per language to given writer
+func (j *StatsJSON) RenderStats(out io.Writer, stats []*indiff.LangStats) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
@@ -0,0 +1,47 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// TAP renderer is producing Test Anything Protocol (version 13) output with one failed test per difference.
+// Details of each difference are attached as YAML diagnostic block.
+type TAP struct {
+	RootPath string
+	Order    indiff.Order
+}
+
+// Render prints given differences as TAP stream to given writer
+func (t *TAP) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, t.Order)
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "TAP version 13\n")
+	if len(diffs) == 0 {
+		fmt.Fprintf(b, "1..0 # SKIP no differences found\n")
+	} else {
+		fmt.Fprintf(b, "1..%d\n", len(diffs))
+	}
+	for i, d := range diffs {
+		path := relativePath(t.RootPath, d.Base())
+		line, err := annotatedLine(d)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "not ok %d - %s\n", i+1, strings.Replace(describe(d, path, t.RootPath), "#", "\\#", -1))
+		fmt.Fprintf(b, "  ---\n")
+		fmt.Fprintf(b, "  kind: %s\n", d.Kind())
+		fmt.Fprintf(b, "  lang: %s\n", d.Lang())
+		fmt.Fprintf(b, "  base: %q\n", path)
+		if tr := d.Translation(); tr != nil {
+			fmt.Fprintf(b, "  translation: %q\n", relativePath(t.RootPath, tr))
+		}
+		fmt.Fprintf(b, "  line: %d\n", line)
+		fmt.Fprintf(b, "  ...\n")
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
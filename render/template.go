@@ -0,0 +1,193 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+)
+
+// Template renderer executes user defined text/template from file on Path with TemplateData.
+// Besides built-in functions of text/template, template can use following functions:
+//
+//	rel PATH      path relative to root directory
+//	json VALUE    value encoded as JSON (e.g. quoted and escaped string)
+//	lines HUNKS   lines of hunks (e.g. .BaseHunks), each with Text, Class (hunk, add, del or ctx), OldNumber and NewNumber
+//	kinds         all kinds of differences in reporting order
+type Template struct {
+	// Path is path to template file
+	Path     string
+	RootPath string
+	BaseLang string
+	// From and To are revisions which define range of changes, empty when not specified
+	From string
+	To   string
+	// Revision is resolved hash of newer revision, empty without git
+	Revision string
+	Stats    []*indiff.LangStats
+	Order    indiff.Order
+}
+
+// TemplateData is data model which is passed to user defined template
+type TemplateData struct {
+	Root     string
+	BaseLang string
+	From     string
+	To       string
+	Revision string
+	// Total is count of all differences
+	Total int
+	// Diffs contains all differences sorted in requested order
+	Diffs []*TemplateDiff
+	// Langs contains differences grouped by language and kind, languages are sorted
+	Langs []*TemplateLang
+	// Stats contains translation statistics of each language
+	Stats []*indiff.LangStats
+}
+
+// TemplateLang holds differences in one language
+type TemplateLang struct {
+	Lang  string
+	Total int
+	// Kinds contains only kinds with at least one difference
+	Kinds []*TemplateKind
+}
+
+// TemplateKind holds differences of one kind
+type TemplateKind struct {
+	Kind  indiff.Kind
+	Diffs []*TemplateDiff
+}
+
+// TemplateDiff is single difference, file paths are absolute.
+// Patches are loaded only when template uses them, so their methods return errors.
+type TemplateDiff struct {
+	Kind indiff.Kind
+	Lang string
+	Base string
+	// Translation is empty for missing translation
+	Translation string
+	diff        indiff.Diff
+}
+
+// BasePatch returns text of base patch in unified format, it is empty when base file was not modified
+func (d *TemplateDiff) BasePatch() (string, error) {
+	patch, err := basePatch(d.diff)
+	return Unified(patch), err
+}
+
+// TranslationPatch returns text of translation patch in unified format, it is empty when translation was not modified
+func (d *TemplateDiff) TranslationPatch() (string, error) {
+	patch, err := translationPatch(d.diff)
+	return Unified(patch), err
+}
+
+// BaseHunks returns structured base patch with line numbers
+func (d *TemplateDiff) BaseHunks() ([]*indiff.Hunk, error) {
+	patch, err := basePatch(d.diff)
+	return hunks(patch), err
+}
+
+// TranslationHunks returns structured translation patch with line numbers
+func (d *TemplateDiff) TranslationHunks() ([]*indiff.Hunk, error) {
+	patch, err := translationPatch(d.diff)
+	return hunks(patch), err
+}
+
+// Line returns first changed line of base file or 1 when it is unknown
+func (d *TemplateDiff) Line() (int, error) {
+	return annotatedLine(d.diff)
+}
+
+// Render executes template with given differences and prints result to given writer.
+// Nothing is printed when template fails.
+func (t *Template) Render(out io.Writer, diffs indiff.Diffs) error {
+	diffs = indiff.Sort(diffs, t.Order)
+	content, err := ioutil.ReadFile(t.Path)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read template: %s", t.Path)
+	}
+	tmpl, err := template.New(filepath.Base(t.Path)).Funcs(t.funcs()).Parse(string(content))
+	if err != nil {
+		return errors.Wrapf(err, "Invalid template: %s", t.Path)
+	}
+
+	data, err := t.data(diffs)
+	if err != nil {
+		return err
+	}
+	b := &strings.Builder{}
+	if err := tmpl.Execute(b, data); err != nil {
+		return errors.Wrapf(err, "Unable to execute template: %s", t.Path)
+	}
+	_, err = io.WriteString(out, b.String())
+	return err
+}
+
+// funcs returns helper functions available in template
+func (t *Template) funcs() template.FuncMap {
+	return template.FuncMap{
+		"rel": func(path string) string {
+			return relativePath(t.RootPath, indiff.NewFile(path, ""))
+		},
+		"json": func(v interface{}) (string, error) {
+			content, err := json.Marshal(v)
+			return string(content), err
+		},
+		"lines": func(hunks []*indiff.Hunk) []patchLine {
+			return patchLines(&indiff.Patch{Hunks: hunks})
+		},
+		"kinds": func() []indiff.Kind { return indiff.Kinds },
+	}
+}
+
+// data converts given differences to data model of template
+func (t *Template) data(diffs indiff.Diffs) (*TemplateData, error) {
+	data := &TemplateData{
+		Root:     t.RootPath,
+		BaseLang: t.BaseLang,
+		From:     t.From,
+		To:       t.To,
+		Revision: t.Revision,
+		Total:    len(diffs),
+		Diffs:    []*TemplateDiff{},
+		Langs:    []*TemplateLang{},
+		Stats:    t.Stats,
+	}
+	converted := map[indiff.Diff]*TemplateDiff{}
+	for _, d := range diffs {
+		td := &TemplateDiff{Kind: d.Kind(), Lang: d.Lang(), Base: d.Base().Path, diff: d}
+		if tr := d.Translation(); tr != nil {
+			td.Translation = tr.Path
+		}
+		converted[d] = td
+		data.Diffs = append(data.Diffs, td)
+	}
+
+	// languages are grouped in their order whatever order of differences is
+	for _, group := range groupDiffs(indiff.Sort(diffs, indiff.OrderLang), indiff.OrderLang) {
+		tl := &TemplateLang{Lang: group.title, Total: len(group.diffs)}
+		for _, kindGroup := range group.groups {
+			tk := &TemplateKind{Kind: indiff.Kind(kindGroup.title)}
+			for _, d := range kindGroup.diffs {
+				tk.Diffs = append(tk.Diffs, converted[d])
+			}
+			tl.Kinds = append(tl.Kinds, tk)
+		}
+		data.Langs = append(data.Langs, tl)
+	}
+	return data, nil
+}
+
+// hunks returns hunks of given patch or nil when patch is not known
+func hunks(patch *indiff.Patch) []*indiff.Hunk {
+	if patch == nil {
+		return nil
+	}
+	return patch.Hunks
+}
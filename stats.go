@@ -0,0 +1,78 @@
+package indiff
+
+// LangStats holds translation statistics for one language
+type LangStats struct {
+	// Lang is language code
+	Lang string `json:"lang"`
+	// BaseFiles is count of files in base language
+	BaseFiles int `json:"baseFiles"`
+	// Translated is count of base files with translation in this language
+	Translated int `json:"translated"`
+	// Missing is count of base files without translation in this language
+	Missing int `json:"missing"`
+	// Stale is count of translations which base file was modified without translation or which are possibly stale.
+	// Translation reported by several diff tools is counted once.
+	Stale int `json:"stale"`
+	// Coverage is percentage of translated base files
+	Coverage float64 `json:"coverage"`
+	// Words is count of words in all files in this language (only when requested)
+	Words *int `json:"words,omitempty"`
+}
+
+// NewStats calculates statistics for each of given languages from given bundle and diffs
+func NewStats(bundle *Bundle, langs []string, diffs Diffs) []*LangStats {
+	type translation struct {
+		lang string
+		path string
+	}
+	seen := map[translation]bool{}
+	stale := map[string]int{}
+	for _, d := range diffs {
+		t := translation{lang: d.Lang(), path: d.Base().Path}
+		if (d.Kind() == KindModifiedBase || d.Kind() == KindPossiblyStale) && !seen[t] {
+			seen[t] = true
+			stale[d.Lang()]++
+		}
+	}
+
+	baseFiles := len(bundle.BasePaths())
+	stats := make([]*LangStats, len(langs))
+	for i, lang := range langs {
+		translated := baseFiles
+		if lang != bundle.BaseLang() {
+			translated = 0
+			for _, p := range bundle.BasePaths() {
+				if bundle.FileInLang(p, lang) != nil {
+					translated++
+				}
+			}
+		}
+		stats[i] = &LangStats{
+			Lang:       lang,
+			BaseFiles:  baseFiles,
+			Translated: translated,
+			Missing:    baseFiles - translated,
+			Stale:      stale[lang],
+			Coverage:   bundle.Coverage(lang),
+		}
+	}
+	return stats
+}
+
+// CountWords counts words in all files of each language in given stats.
+// Words are counted same way as in EstimateEffort, so front matter, code blocks and markup are not counted.
+func CountWords(bundle *Bundle, stats []*LangStats) error {
+	for _, s := range stats {
+		words := 0
+		for _, f := range bundle.FilesForLang(s.Lang) {
+			content, err := readFile(f.Path)
+			if err != nil {
+				return err
+			}
+			count, _ := countWords(content)
+			words += count
+		}
+		s.Words = &words
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package indiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewStats(t *testing.T) {
+
+	// Given bundle with "en" as base language, one of two files translated to "de" and both translated to "sk"
+	bundle := NewBundle("en", Files{
+		NewFile("en/first.md", "en"),
+		NewFile("en/second.md", "en"),
+		NewFile("de/first.md", "de"),
+		NewFile("sk/first.md", "sk"),
+		NewFile("sk/second.md", "sk"),
+	})
+
+	// Given diffs with missing, modified base and possibly stale files, one translation reported twice
+	diffs := Diffs{
+		NewMissing(NewFile("en/second.md", "en"), "de"),
+		NewModifiedBase(NewFile("en/first.md", "en").Modified(nil), NewFile("de/first.md", "de")),
+		NewPossiblyStale(NewFile("en/first.md", "en"), NewFile("de/first.md", "de")),
+		NewPossiblyStale(NewFile("en/second.md", "en"), NewFile("sk/second.md", "sk")),
+	}
+
+	// When stats are calculated
+	stats := NewStats(bundle, []string{"en", "de", "sk"}, diffs)
+
+	// Then each language should have own stats
+	expected := []*LangStats{
+		{Lang: "en", BaseFiles: 2, Translated: 2, Missing: 0, Stale: 0, Coverage: 100},
+		{Lang: "de", BaseFiles: 2, Translated: 1, Missing: 1, Stale: 1, Coverage: 50},
+		{Lang: "sk", BaseFiles: 2, Translated: 2, Missing: 0, Stale: 1, Coverage: 100},
+	}
+	if !reflect.DeepEqual(expected, stats) {
+		t.Errorf("Unexpected stats. Should be `%+v` but was `%+v`", expected, stats)
+	}
+}
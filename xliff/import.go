@@ -0,0 +1,115 @@
+package xliff
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/handoff"
+)
+
+// Import rebuilds translated files from XLIFF documents and places them to paths defined by pattern
+type Import struct {
+	// Root is directory to which paths in documents are relative
+	Root string
+	// Pattern is used to derive paths of translation files
+	Pattern filesystem.Pattern
+	// BaseLang is language of base files, source language of document must be same
+	BaseLang string
+	// Langs are languages of translations, target language of document must be one of them
+	Langs []string
+	// Force enables overwriting of translations modified since export
+	Force bool
+}
+
+// ImportReport summarizes result of import of one document. All paths are slash separated and relative to root.
+type ImportReport struct {
+	// Imported holds paths to written translation files
+	Imported []string
+	// Untranslated holds count of segments without translation in each written file
+	Untranslated map[string]int
+	// Skipped holds paths to translation files which were not written as none of their segments was translated
+	Skipped []string
+	// Conflicts holds paths to translation files which were modified since export and were not overwritten
+	Conflicts []string
+}
+
+// Write writes translated files from given document
+func (i *Import) Write(doc *Document) (*ImportReport, error) {
+	if doc.SrcLang != i.BaseLang {
+		return nil, errors.Errorf("Source language '%s' of document differs from base language '%s'", doc.SrcLang, i.BaseLang)
+	}
+	if doc.TrgLang == i.BaseLang || !contains(i.Langs, doc.TrgLang) {
+		return nil, errors.Errorf("Target language '%s' of document is not one of translation languages", doc.TrgLang)
+	}
+
+	report := &ImportReport{Imported: []string{}, Untranslated: map[string]int{}, Skipped: []string{}, Conflicts: []string{}}
+	for _, f := range doc.Files {
+		if err := i.writeFile(doc, f, report); err != nil {
+			return nil, errors.Wrapf(err, "Unable to import translation of: %s", f.Original)
+		}
+	}
+	return report, nil
+}
+
+// writeFile writes translation of single file and records result to report
+func (i *Import) writeFile(doc *Document, f *File, report *ImportReport) error {
+	base, err := handoff.ResolvePath(i.Root, f.Original)
+	if err != nil {
+		return err
+	}
+	rel, ok, err := i.Pattern.TranslationPath(filepath.FromSlash(f.Original), doc.SrcLang, doc.TrgLang)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("Unable to derive path of translation to %s", doc.TrgLang)
+	}
+	target := filepath.ToSlash(rel)
+	abs, err := handoff.ResolvePath(i.Root, target)
+	if err != nil {
+		return err
+	}
+	if abs == base {
+		return errors.Errorf("Translation can't overwrite base file: %s", f.Original)
+	}
+
+	content, untranslated := f.Rebuild()
+	if untranslated == len(f.Units) {
+		report.Skipped = append(report.Skipped, target)
+		return nil
+	}
+
+	// refuse to overwrite translation modified since export
+	if !i.Force {
+		if err := handoff.CheckUnmodified(abs, f.TranslationHash); err == handoff.ErrModifiedSinceExport {
+			report.Conflicts = append(report.Conflicts, target)
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(abs), os.FileMode(0755)); err != nil {
+		return errors.Wrapf(err, "Unable to create directory: %s", filepath.Dir(abs))
+	}
+	if err := ioutil.WriteFile(abs, []byte(content), os.FileMode(0644)); err != nil {
+		return errors.Wrapf(err, "Unable to write file: %s", abs)
+	}
+	report.Imported = append(report.Imported, target)
+	if untranslated > 0 {
+		report.Untranslated[target] = untranslated
+	}
+	return nil
+}
+
+func contains(xs []string, x string) bool {
+	for _, s := range xs {
+		if s == x {
+			return true
+		}
+	}
+	return false
+}
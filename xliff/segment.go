@@ -0,0 +1,114 @@
+package xliff
+
+import (
+	"strings"
+
+	"github.com/unravela/indiff"
+)
+
+// Paragraphs splits given text to paragraphs separated by blank lines.
+// Fenced code blocks are kept in single paragraph even if they contain blank lines.
+// Lines are kept verbatim, so trailing spaces (e.g. Markdown hard line breaks) are not lost.
+func Paragraphs(text string) []string {
+	return texts(splitParagraphs(text))
+}
+
+// paragraph is text of paragraph with numbers of its first and last line (numbered from 1)
+type paragraph struct {
+	text  string
+	first int
+	last  int
+}
+
+// splitParagraphs splits given text to paragraphs, see Paragraphs
+func splitParagraphs(text string) []paragraph {
+	paragraphs := []paragraph{}
+	current := []string{}
+	first := 0
+	inFence := false
+	flush := func(last int) {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, paragraph{text: strings.Join(current, "\n"), first: first, last: last})
+			current = []string{}
+		}
+	}
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if trimmed == "" && !inFence {
+			flush(i)
+			continue
+		}
+		if len(current) == 0 {
+			first = i + 1
+		}
+		current = append(current, line)
+	}
+	flush(len(lines))
+	return paragraphs
+}
+
+// texts returns texts of given paragraphs
+func texts(paragraphs []paragraph) []string {
+	result := make([]string, len(paragraphs))
+	for i, p := range paragraphs {
+		result[i] = p.text
+	}
+	return result
+}
+
+// changedParagraphs returns indexes of paragraphs containing some line added by given patch.
+// Added lines are located by their line numbers, so lines repeated elsewhere in file don't mark other paragraphs.
+func changedParagraphs(paragraphs []paragraph, patch *indiff.Patch) map[int]bool {
+	changed := map[int]bool{}
+	for _, line := range patch.Lines(indiff.LineAdded) {
+		for i, p := range paragraphs {
+			if line.NewNumber >= p.first && line.NewNumber <= p.last {
+				changed[i] = true
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// alignParagraphs pairs unchanged base paragraphs with paragraphs of translation and returns translation for each paired base paragraph.
+// Paragraphs before first change are paired from start and paragraphs after last change are paired from end.
+// Paragraphs between changes are paired only when base and translation have same count of paragraphs.
+func alignParagraphs(base []string, translation []string, changed map[int]bool) map[int]string {
+	targets := map[int]string{}
+	if len(translation) == 0 {
+		return targets
+	}
+	first, last := len(base), -1
+	for i := range changed {
+		if i < first {
+			first = i
+		}
+		if i > last {
+			last = i
+		}
+	}
+	offset := len(translation) - len(base)
+	for i := range base {
+		if changed[i] {
+			continue
+		}
+		t := -1
+		switch {
+		case i < first && i < len(translation):
+			t = i
+		case i > last && i+offset >= 0:
+			t = i + offset
+		case offset == 0:
+			t = i
+		}
+		if t >= 0 {
+			targets[i] = translation[t]
+		}
+	}
+	return targets
+}
@@ -0,0 +1,171 @@
+package xliff
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/handoff"
+)
+
+// Segment states defined by XLIFF 2.0
+const (
+	StateInitial    = "initial"
+	StateTranslated = "translated"
+)
+
+// Document is root element of XLIFF 2.0 document
+type Document struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:xliff:document:2.0 xliff"`
+	Version string   `xml:"version,attr"`
+	SrcLang string   `xml:"srcLang,attr"`
+	TrgLang string   `xml:"trgLang,attr"`
+	Files   []*File  `xml:"file"`
+}
+
+// File holds translation units of one base file
+type File struct {
+	ID string `xml:"id,attr"`
+	// Original is slash separated path to base file relative to root directory
+	Original string `xml:"original,attr"`
+	// TranslationHash is SHA-256 hash of translation file at the time of export, empty for missing translation
+	TranslationHash string  `xml:"https://github.com/unravela/indiff translationHash,attr,omitempty"`
+	Units           []*Unit `xml:"unit"`
+}
+
+// Unit holds one paragraph of base file
+type Unit struct {
+	ID      string   `xml:"id,attr"`
+	Segment *Segment `xml:"segment"`
+}
+
+// Segment holds source text and its translation
+type Segment struct {
+	State  string  `xml:"state,attr,omitempty"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target,omitempty"`
+}
+
+// Converter converts Missing and ModifiedBase diffs to XLIFF documents
+type Converter struct {
+	// Root is directory to which paths in document are relative
+	Root string
+	// BaseLang is language of base files
+	BaseLang string
+}
+
+// Convert creates document with base files from given diffs in given language.
+// Every paragraph of base file is one unit. Paragraphs of missing translations and paragraphs changed
+// in modified base files are in initial state, other paragraphs are pre-filled from current translation.
+// Changes of possibly stale base files are unknown, so all their paragraphs are pre-filled but left in initial state.
+func (c *Converter) Convert(lang string, diffs indiff.Diffs) (*Document, error) {
+	doc := &Document{Version: "2.0", SrcLang: c.BaseLang, TrgLang: lang, Files: []*File{}}
+	for _, d := range diffs {
+		if d.Lang() != lang {
+			continue
+		}
+
+		switch d.(type) {
+		case *indiff.Missing, *indiff.ModifiedBase, *indiff.PossiblyStale:
+		default:
+			continue
+		}
+
+		content, err := ioutil.ReadFile(d.Base().Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to read base file: %s", d.Base().Path)
+		}
+		paragraphs := splitParagraphs(string(content))
+		base := texts(paragraphs)
+
+		var translation []string
+		var changed map[int]bool
+		var translationHash string
+		if t := d.Translation(); t != nil {
+			content, err := ioutil.ReadFile(t.Path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to read translation file: %s", t.Path)
+			}
+			translation = Paragraphs(string(content))
+			translationHash = handoff.HashOf(content)
+		}
+		if mb, ok := d.(*indiff.ModifiedBase); ok {
+			patch, err := mb.BasePatch()
+			if err != nil {
+				return nil, err
+			}
+			changed = changedParagraphs(paragraphs, patch)
+		}
+		_, isStale := d.(*indiff.PossiblyStale)
+
+		f := &File{ID: fmt.Sprintf("f%d", len(doc.Files)+1), Original: c.relative(d.Base()), TranslationHash: translationHash}
+		targets := alignParagraphs(base, translation, changed)
+		for i, p := range base {
+			s := &Segment{State: StateInitial, Source: p}
+			if t, ok := targets[i]; ok {
+				s.Target = &t
+				if !isStale {
+					s.State = StateTranslated
+				}
+			}
+			f.Units = append(f.Units, &Unit{ID: fmt.Sprintf("u%d", i+1), Segment: s})
+		}
+		doc.Files = append(doc.Files, f)
+	}
+	return doc, nil
+}
+
+// relative returns slash separated path of given file relative to root
+func (c *Converter) relative(f *indiff.File) string {
+	rel, err := filepath.Rel(c.Root, f.Path)
+	if err != nil {
+		rel = f.Path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Write writes document as XML to given writer
+func (doc *Document) Write(out io.Writer) error {
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return errors.Wrap(err, "Unable to write XLIFF document")
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+// Read parses XLIFF document from given reader
+func Read(in io.Reader) (*Document, error) {
+	doc := &Document{}
+	if err := xml.NewDecoder(in).Decode(doc); err != nil {
+		return nil, errors.Wrap(err, "Invalid XLIFF document")
+	}
+	if doc.Version != "2.0" {
+		return nil, errors.Errorf("Unsupported XLIFF version: %s", doc.Version)
+	}
+	return doc, nil
+}
+
+// Rebuild joins translated paragraphs of file back to document.
+// Source is used for paragraphs without translation and their count is returned.
+func (f *File) Rebuild() (content string, untranslated int) {
+	paragraphs := make([]string, len(f.Units))
+	for i, u := range f.Units {
+		if u.Segment.Target != nil && *u.Segment.Target != "" {
+			paragraphs[i] = *u.Segment.Target
+		} else {
+			paragraphs[i] = u.Segment.Source
+			untranslated++
+		}
+	}
+	return strings.Join(paragraphs, "\n\n") + "\n", untranslated
+}
@@ -0,0 +1,274 @@
+package xliff
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/unravela/indiff"
+	"github.com/unravela/indiff/filesystem"
+	"github.com/unravela/indiff/internal/patchtest"
+)
+
+func TestConvert(t *testing.T) {
+
+	// Given base file with new paragraph in the middle and its outdated translation
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	base := writeFile(t, root, "en/first.md", "# First\n\nNew paragraph\n\n```\ncode\n\nblock\n```\n\nLast one\n")
+	translation := writeFile(t, root, "de/first.md", "# Erste\n\n```\ncode\n\nblock\n```\n\nLetzte\n")
+	patch, err := patchtest.Parse("@@ -1,7 +1,9 @@\n # First\n \n+New paragraph\n+\n ```")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := indiff.NewModifiedBase(indiff.NewFile(base, "en").Modified(patch), indiff.NewFile(translation, "de"))
+
+	// When diff is converted to XLIFF and back
+	converter := &Converter{Root: root, BaseLang: "en"}
+	doc, err := converter.Convert("de", indiff.Diffs{diff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	if err := doc.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	doc, err = Read(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then only new paragraph should need translation and others should be pre-filled from translation
+	states := []string{}
+	for _, u := range doc.Files[0].Units {
+		states = append(states, u.Segment.State)
+	}
+	expected := []string{StateTranslated, StateInitial, StateTranslated, StateTranslated}
+	if !reflect.DeepEqual(expected, states) {
+		t.Errorf("Unexpected segment states. Should be `%v` but was `%v`", expected, states)
+	}
+
+	// Then rebuilt document should contain translations and source of untranslated paragraph
+	content, untranslated := doc.Files[0].Rebuild()
+	if expected := "# Erste\n\nNew paragraph\n\n```\ncode\n\nblock\n```\n\nLetzte\n"; content != expected || untranslated != 1 {
+		t.Errorf("Unexpected rebuilt document. Should be `%s` but was `%s`", expected, content)
+	}
+}
+
+func TestConvertRepeatedLines(t *testing.T) {
+
+	// Given base file with added paragraph ending by line which is also in unchanged paragraph
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	base := writeFile(t, root, "en/first.md", "Intro\n---\n\nText\n\nAdded\n---\n")
+	translation := writeFile(t, root, "de/first.md", "Einleitung\n---\n\nText\n")
+	patch, err := patchtest.Parse("@@ -1,4 +1,7 @@\n Intro\n ---\n \n Text\n+\n+Added\n+---")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := indiff.NewModifiedBase(indiff.NewFile(base, "en").Modified(patch), indiff.NewFile(translation, "de"))
+
+	// When diff is converted to XLIFF
+	doc, err := (&Converter{Root: root, BaseLang: "en"}).Convert("de", indiff.Diffs{diff})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then only added paragraph should need translation
+	states := []string{}
+	for _, u := range doc.Files[0].Units {
+		states = append(states, u.Segment.State)
+	}
+	expected := []string{StateTranslated, StateTranslated, StateInitial}
+	if !reflect.DeepEqual(expected, states) {
+		t.Errorf("Unexpected segment states. Should be `%v` but was `%v`", expected, states)
+	}
+}
+
+func TestRoundTripHardBreak(t *testing.T) {
+
+	// Given missing translation of base file with Markdown hard line break (two trailing spaces)
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	content := "Line one  \nLine two\n\nNext\n"
+	base := writeFile(t, root, "en/first.md", content)
+	diff := indiff.NewMissing(indiff.NewFile(base, "en"), "de")
+
+	// When diff is exported to XLIFF, translated as is and imported back
+	doc, err := (&Converter{Root: root, BaseLang: "en"}).Convert("de", indiff.Diffs{diff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	if err := doc.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	doc, err = Read(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range doc.Files[0].Units {
+		target := u.Segment.Source
+		u.Segment.Target = &target
+	}
+	rebuilt, _ := doc.Files[0].Rebuild()
+
+	// Then hard line break should be kept
+	if rebuilt != content {
+		t.Errorf("Unexpected rebuilt document. Should be `%q` but was `%q`", content, rebuilt)
+	}
+}
+
+func TestConvertPossiblyStale(t *testing.T) {
+
+	// Given possibly stale translation without known changes of base file
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	base := writeFile(t, root, "en/first.md", "# First\n\nChanged paragraph\n")
+	translation := writeFile(t, root, "de/first.md", "# Erste\n\nAbsatz\n")
+	diff := indiff.NewPossiblyStale(indiff.NewFile(base, "en"), indiff.NewFile(translation, "de"))
+
+	// When diff is converted to XLIFF
+	doc, err := (&Converter{Root: root, BaseLang: "en"}).Convert("de", indiff.Diffs{diff})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then all paragraphs should be pre-filled from translation but left for review in initial state
+	for i, expected := range []string{"# Erste", "Absatz"} {
+		s := doc.Files[0].Units[i].Segment
+		if s.State != StateInitial || s.Target == nil || *s.Target != expected {
+			t.Errorf("Unexpected segment %d. Should be initial with target `%s` but was `%+v`", i, expected, s)
+		}
+	}
+}
+
+func TestImportConflict(t *testing.T) {
+
+	// Given document exported from modified base file and its translation
+	root, err := ioutil.TempDir("", "indiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	base := writeFile(t, root, "en/first.md", "# First\n\nNew paragraph\n")
+	translation := writeFile(t, root, "de/first.md", "# Erste\n")
+	patch, err := patchtest.Parse("@@ -1,1 +1,3 @@\n # First\n+\n+New paragraph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := indiff.NewModifiedBase(indiff.NewFile(base, "en").Modified(patch), indiff.NewFile(translation, "de"))
+	doc, err := (&Converter{Root: root, BaseLang: "en"}).Convert("de", indiff.Diffs{diff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := "Neuer Absatz"
+	doc.Files[0].Units[1].Segment.Target = &target
+
+	// Given translation modified after export
+	writeFile(t, root, "de/first.md", "# Erste!\n")
+
+	// When document is imported
+	i := &Import{Root: root, Pattern: filesystem.MustParsePattern("SUB", nil), BaseLang: "en", Langs: []string{"en", "de"}}
+	report, err := i.Write(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then translation should be reported as conflict and kept
+	if expected := []string{"de/first.md"}; !reflect.DeepEqual(expected, report.Conflicts) || len(report.Imported) != 0 {
+		t.Errorf("Unexpected conflicts. Should be `%v` but was `%v`", expected, report.Conflicts)
+	}
+	assertContent(t, translation, "# Erste!\n")
+
+	// When document is imported with force
+	i.Force = true
+	report, err = i.Write(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then translation should be overwritten
+	if expected := []string{"de/first.md"}; !reflect.DeepEqual(expected, report.Imported) {
+		t.Errorf("Unexpected imported files. Should be `%v` but was `%v`", expected, report.Imported)
+	}
+	assertContent(t, translation, "# Erste\n\nNeuer Absatz\n")
+}
+
+func TestImportInvalid(t *testing.T) {
+
+	tests := map[string]*Document{
+		"source language":  {SrcLang: "de", TrgLang: "en", Files: []*File{{Original: "en/first.md"}}},
+		"base language":    {SrcLang: "en", TrgLang: "en", Files: []*File{{Original: "en/first.md"}}},
+		"unknown language": {SrcLang: "en", TrgLang: "fr", Files: []*File{{Original: "en/first.md"}}},
+		"outside":          {SrcLang: "en", TrgLang: "de", Files: []*File{{Original: "en/../../en/first.md"}}},
+		"absolute":         {SrcLang: "en", TrgLang: "de", Files: []*File{{Original: "/en/first.md"}}},
+	}
+
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Given document with invalid language or path
+			root, err := ioutil.TempDir("", "indiff")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(root)
+			base := writeFile(t, root, "en/first.md", "# First\n")
+			target := "# Übersetzt"
+			for _, f := range doc.Files {
+				f.Units = []*Unit{{ID: "u1", Segment: &Segment{Source: "# First", Target: &target}}}
+			}
+
+			// When document is imported
+			i := &Import{Root: root, Pattern: filesystem.MustParsePattern("SUB", nil), BaseLang: "en", Langs: []string{"en", "de"}}
+			_, err = i.Write(doc)
+
+			// Then import should fail without writing anything
+			if err == nil {
+				t.Errorf("Unexpected success of import")
+			}
+			assertContent(t, base, "# First\n")
+			if _, err := os.Stat(filepath.Join(filepath.Dir(root), "de")); err == nil {
+				t.Errorf("Unexpected directory written outside of root")
+			}
+		})
+	}
+}
+
+// helpers
+
+func writeFile(t *testing.T, root string, rel string, content string) string {
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func assertContent(t *testing.T, path string, expected string) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != expected {
+		t.Errorf("Unexpected content of %s. Should be `%s` but was `%s`", path, expected, content)
+	}
+}